@@ -0,0 +1,292 @@
+// Package ansi tokenizes CP437 text that uses ANSI escape sequences.
+//
+// ANSI art is a stream of characters and control sequences that a viewer,
+// such as the MS-DOS ANSI.SYS driver or a BBS terminal, interprets to place
+// colored characters on a text mode screen. The tokenizer splits the stream
+// into runs of text, control codes and escape sequences, with each token
+// keeping its byte offset within the original stream.
+//
+// See https://en.wikipedia.org/wiki/ANSI_escape_code and
+// http://www.acid.org/info/sauce/sauce.htm#FileType
+package ansi
+
+import (
+	"iter"
+
+	"github.com/bengarrett/sauce/internal/cp437"
+)
+
+// Control codes interpreted by a text mode screen.
+const (
+	BEL byte = 0x07 // bell
+	BS  byte = 0x08 // backspace
+	TAB byte = 0x09 // horizontal tab
+	LF  byte = 0x0a // line feed
+	FF  byte = 0x0c // form feed
+	CR  byte = 0x0d // carriage return
+	SO  byte = 0x0e // shift out, the ANSI music terminator
+	SUB byte = 0x1a // substitute, the end-of-file marker
+	ESC byte = 0x1b // escape
+)
+
+// Kind is the type of token.
+type Kind uint8
+
+const (
+	Text           Kind = iota // a run of printable CP437 characters
+	Control                    // a control code such as CR, LF, TAB or SUB
+	SGR                        // select graphic rendition, to set the colors and text attributes
+	CursorUp                   // move the cursor up
+	CursorDown                 // move the cursor down
+	CursorForward              // move the cursor right
+	CursorBack                 // move the cursor left
+	CursorPosition             // move the cursor to a row and column
+	CursorColumn               // move the cursor to a column of the current row
+	EraseDisplay               // erase some or all of the screen
+	EraseLine                  // erase some or all of the current line
+	Save                       // save the cursor position
+	Restore                    // restore the saved cursor position
+	Mode                       // set or reset a screen mode, such as line wrapping
+	RGB                        // a PabloDraw 24-bit color sequence
+	Music                      // an ANSI music sequence
+	Unknown                    // an unsupported or malformed escape sequence
+)
+
+func (k Kind) String() string {
+	if k > Unknown {
+		return ""
+	}
+	return [...]string{
+		"text",
+		"control",
+		"select graphic rendition",
+		"cursor up",
+		"cursor down",
+		"cursor forward",
+		"cursor back",
+		"cursor position",
+		"cursor column",
+		"erase display",
+		"erase line",
+		"save cursor",
+		"restore cursor",
+		"mode",
+		"24-bit color",
+		"music",
+		"unknown",
+	}[k]
+}
+
+// Token is a run of text, a control code or an escape sequence.
+type Token struct {
+	Kind    Kind   // kind of token
+	Offset  int    // offset is the position of the first byte of the token in the stream
+	Raw     []byte // raw bytes of the token
+	Params  []int  // numeric parameters of an escape sequence, with omitted values as 0
+	Private byte   // private parameter prefix of an escape sequence, such as '?' or '='
+	Final   byte   // final byte of an escape sequence or the control code
+}
+
+// Param returns the numeric parameter at index i,
+// or def when the parameter is omitted or 0.
+func (t Token) Param(i, def int) int {
+	if i < 0 || i >= len(t.Params) || t.Params[i] == 0 {
+		return def
+	}
+	return t.Params[i]
+}
+
+// Text returns the text of the token decoded from CP437 to UTF-8.
+// An empty string is returned for tokens that are not [Text].
+func (t Token) Text() string {
+	if t.Kind != Text {
+		return ""
+	}
+	return cp437.String(t.Raw)
+}
+
+// Tokenize returns the tokens of the ANSI text b.
+func Tokenize(b []byte) []Token {
+	toks := []Token{}
+	for t := range All(b) {
+		toks = append(toks, t)
+	}
+	return toks
+}
+
+// All returns an iterator over the tokens of the ANSI text b.
+func All(b []byte) iter.Seq[Token] {
+	return func(yield func(Token) bool) {
+		for i := 0; i < len(b); {
+			t := next(b, i)
+			if !yield(t) {
+				return
+			}
+			i += len(t.Raw)
+		}
+	}
+}
+
+// IsControl reports whether c is a control code that is interpreted by a text mode screen.
+// The other C0 control codes are displayed as glyphs, such as ☺ and ♥.
+func IsControl(c byte) bool {
+	switch c {
+	case BEL, BS, TAB, LF, CR, SUB, ESC:
+		return true
+	}
+	return false
+}
+
+// next returns the token found at position i of b.
+func next(b []byte, i int) Token {
+	c := b[i]
+	switch {
+	case c == ESC:
+		return escape(b, i)
+	case IsControl(c):
+		return Token{Kind: Control, Offset: i, Raw: b[i : i+1], Final: c}
+	}
+	j := i + 1
+	for j < len(b) && !IsControl(b[j]) {
+		j++
+	}
+	return Token{Kind: Text, Offset: i, Raw: b[i:j]}
+}
+
+// escape returns the escape sequence found at position i of b.
+func escape(b []byte, i int) Token {
+	const csi = '['
+	if i+1 >= len(b) {
+		return Token{Kind: Unknown, Offset: i, Raw: b[i : i+1]}
+	}
+	switch c := b[i+1]; c {
+	case csi:
+		return sequence(b, i)
+	case '7':
+		return Token{Kind: Save, Offset: i, Raw: b[i : i+2], Final: c}
+	case '8':
+		return Token{Kind: Restore, Offset: i, Raw: b[i : i+2], Final: c}
+	default:
+		return Token{Kind: Unknown, Offset: i, Raw: b[i : i+2], Final: c}
+	}
+}
+
+// sequence returns the control sequence introducer, ESC [, found at position i of b.
+func sequence(b []byte, i int) Token {
+	t := Token{Kind: Unknown, Offset: i}
+	j := i + len("\x1b[")
+	if j < len(b) && isPrivate(b[j]) {
+		t.Private = b[j]
+		j++
+	}
+	params := j
+	// parameter bytes
+	for j < len(b) && b[j] >= 0x30 && b[j] <= 0x3f {
+		j++
+	}
+	t.Params = parameters(b[params:j])
+	// intermediate bytes
+	for j < len(b) && b[j] >= 0x20 && b[j] <= 0x2f {
+		j++
+	}
+	if j >= len(b) || b[j] < 0x40 || b[j] > 0x7e {
+		// a malformed sequence that is interrupted by an invalid byte
+		t.Raw = b[i:j]
+		return t
+	}
+	t.Final = b[j]
+	t.Raw = b[i : j+1]
+	if t.Private == 0 && len(t.Params) == 0 && (t.Final == 'M' || t.Final == 'N') {
+		if end := music(b, j+1); end > -1 {
+			t.Kind = Music
+			t.Raw = b[i : end+1]
+			return t
+		}
+	}
+	t.Kind = kind(t)
+	return t
+}
+
+// kind returns the kind of the control sequence t.
+func kind(t Token) Kind {
+	if t.Private != 0 {
+		if t.Final == 'h' || t.Final == 'l' {
+			return Mode
+		}
+		return Unknown
+	}
+	switch t.Final {
+	case 'm':
+		return SGR
+	case 'A':
+		return CursorUp
+	case 'B':
+		return CursorDown
+	case 'C':
+		return CursorForward
+	case 'D':
+		return CursorBack
+	case 'H', 'f':
+		return CursorPosition
+	case 'G':
+		return CursorColumn
+	case 'J':
+		return EraseDisplay
+	case 'K':
+		return EraseLine
+	case 's':
+		return Save
+	case 'u':
+		return Restore
+	case 'h', 'l':
+		return Mode
+	case 't':
+		const pablo = 4
+		if len(t.Params) == pablo {
+			return RGB
+		}
+	}
+	return Unknown
+}
+
+func isPrivate(c byte) bool {
+	return c == '?' || c == '=' || c == '>' || c == '<'
+}
+
+// parameters returns the numeric values of the parameter bytes b,
+// which are separated by semicolons or colons.
+func parameters(b []byte) []int {
+	if len(b) == 0 {
+		return nil
+	}
+	const limit = 1 << 16
+	params := make([]int, 0, 4)
+	n := 0
+	for _, c := range b {
+		switch {
+		case c >= '0' && c <= '9':
+			n = min(n*10+int(c-'0'), limit)
+		case c == ';', c == ':':
+			params = append(params, n)
+			n = 0
+		}
+	}
+	return append(params, n)
+}
+
+// music returns the position of the SO terminator of an ANSI music sequence
+// that starts at position i of b, or -1 if the bytes are not ANSI music.
+func music(b []byte, i int) int {
+	const limit = 1024
+	end := min(len(b), i+limit)
+	for j := i; j < end; j++ {
+		c := b[j]
+		switch {
+		case c == SO:
+			return j
+		case c < ' ' || c > '~':
+			return -1
+		}
+	}
+	return -1
+}
@@ -0,0 +1,158 @@
+package ansi_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/bengarrett/sauce/ansi"
+)
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+	type tok struct {
+		kind   ansi.Kind
+		offset int
+		raw    string
+		params []int
+	}
+	tests := []struct {
+		name string
+		b    string
+		want []tok
+	}{
+		{"empty", "", []tok{}},
+		{"text", "Hello", []tok{{ansi.Text, 0, "Hello", nil}}},
+		{"crlf", "Hi\r\n", []tok{
+			{ansi.Text, 0, "Hi", nil},
+			{ansi.Control, 2, "\r", nil},
+			{ansi.Control, 3, "\n", nil},
+		}},
+		{"glyphs", "\x01\x03\x0c", []tok{{ansi.Text, 0, "\x01\x03\x0c", nil}}},
+		{"sgr", "\x1b[0;1;31mA", []tok{
+			{ansi.SGR, 0, "\x1b[0;1;31m", []int{0, 1, 31}},
+			{ansi.Text, 9, "A", nil},
+		}},
+		{"reset", "\x1b[m", []tok{{ansi.SGR, 0, "\x1b[m", nil}}},
+		{"cursor", "\x1b[5C\x1b[2A\x1b[B\x1b[3D", []tok{
+			{ansi.CursorForward, 0, "\x1b[5C", []int{5}},
+			{ansi.CursorUp, 4, "\x1b[2A", []int{2}},
+			{ansi.CursorDown, 8, "\x1b[B", nil},
+			{ansi.CursorBack, 11, "\x1b[3D", []int{3}},
+		}},
+		{"position", "\x1b[;5H\x1b[10;20f", []tok{
+			{ansi.CursorPosition, 0, "\x1b[;5H", []int{0, 5}},
+			{ansi.CursorPosition, 5, "\x1b[10;20f", []int{10, 20}},
+		}},
+		{"erase", "\x1b[2J\x1b[K", []tok{
+			{ansi.EraseDisplay, 0, "\x1b[2J", []int{2}},
+			{ansi.EraseLine, 4, "\x1b[K", nil},
+		}},
+		{"save restore", "\x1b[s\x1b[u\x1b7\x1b8", []tok{
+			{ansi.Save, 0, "\x1b[s", nil},
+			{ansi.Restore, 3, "\x1b[u", nil},
+			{ansi.Save, 6, "\x1b7", nil},
+			{ansi.Restore, 8, "\x1b8", nil},
+		}},
+		{"mode", "\x1b[?7h", []tok{{ansi.Mode, 0, "\x1b[?7h", []int{7}}}},
+		{"pablodraw", "\x1b[1;255;128;0t", []tok{{ansi.RGB, 0, "\x1b[1;255;128;0t", []int{1, 255, 128, 0}}}},
+		{"music", "\x1b[MFT120L8CDE\x0eX", []tok{
+			{ansi.Music, 0, "\x1b[MFT120L8CDE\x0e", nil},
+			{ansi.Text, 14, "X", nil},
+		}},
+		{"delete line", "\x1b[M\r", []tok{
+			{ansi.Unknown, 0, "\x1b[M", nil},
+			{ansi.Control, 3, "\r", nil},
+		}},
+		{"malformed", "\x1b[1;\x01A", []tok{
+			{ansi.Unknown, 0, "\x1b[1;", []int{1, 0}},
+			{ansi.Text, 4, "\x01A", nil},
+		}},
+		{"lone escape", "A\x1b", []tok{
+			{ansi.Text, 0, "A", nil},
+			{ansi.Unknown, 1, "\x1b", nil},
+		}},
+		{"eof", "A\x1aB", []tok{
+			{ansi.Text, 0, "A", nil},
+			{ansi.Control, 1, "\x1a", nil},
+			{ansi.Text, 2, "B", nil},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := ansi.Tokenize([]byte(tt.b))
+			if len(got) != len(tt.want) {
+				t.Errorf("Tokenize() length = %d, want %d: %v", len(got), len(tt.want), got)
+				return
+			}
+			for i, w := range tt.want {
+				g := got[i]
+				if g.Kind != w.kind {
+					t.Errorf("Tokenize()[%d].Kind = %v, want %v", i, g.Kind, w.kind)
+				}
+				if g.Offset != w.offset {
+					t.Errorf("Tokenize()[%d].Offset = %d, want %d", i, g.Offset, w.offset)
+				}
+				if string(g.Raw) != w.raw {
+					t.Errorf("Tokenize()[%d].Raw = %q, want %q", i, g.Raw, w.raw)
+				}
+				if !reflect.DeepEqual(g.Params, w.params) {
+					t.Errorf("Tokenize()[%d].Params = %v, want %v", i, g.Params, w.params)
+				}
+			}
+		})
+	}
+}
+
+func TestToken_Param(t *testing.T) {
+	t.Parallel()
+	tok := ansi.Token{Params: []int{0, 5}}
+	if got := tok.Param(0, 1); got != 1 {
+		t.Errorf("Param() = %d, want %d", got, 1)
+	}
+	if got := tok.Param(1, 1); got != 5 {
+		t.Errorf("Param() = %d, want %d", got, 5)
+	}
+	if got := tok.Param(2, 1); got != 1 {
+		t.Errorf("Param() = %d, want %d", got, 1)
+	}
+}
+
+func TestToken_Text(t *testing.T) {
+	t.Parallel()
+	toks := ansi.Tokenize([]byte("\x1b[31m\xdb\xb2 Hi"))
+	const want = 2
+	if len(toks) != want {
+		t.Errorf("Tokenize() length = %d, want %d", len(toks), want)
+		return
+	}
+	if got := toks[0].Text(); got != "" {
+		t.Errorf("Text() = %q, want an empty string", got)
+	}
+	if got, want := toks[1].Text(), "█▓ Hi"; got != want {
+		t.Errorf("Text() = %q, want %q", got, want)
+	}
+}
+
+func TestKind_String(t *testing.T) {
+	t.Parallel()
+	if got, want := ansi.SGR.String(), "select graphic rendition"; got != want {
+		t.Errorf("Kind.String() = %q, want %q", got, want)
+	}
+	if got := ansi.Kind(255).String(); got != "" {
+		t.Errorf("Kind.String() = %q, want an empty string", got)
+	}
+}
+
+func ExampleAll() {
+	b := []byte("\x1b[1;33mHello\x1b[0m\r\n")
+	for t := range ansi.All(b) {
+		fmt.Printf("%d: %s %q\n", t.Offset, t.Kind, t.Raw)
+	}
+	// Output: 0: select graphic rendition "\x1b[1;33m"
+	// 7: text "Hello"
+	// 12: select graphic rendition "\x1b[0m"
+	// 16: control "\r"
+	// 17: control "\n"
+}
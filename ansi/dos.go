@@ -0,0 +1,254 @@
+package ansi
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// PCBoard returns the tokens of the PCBoard color text b.
+// The token offsets are positions in the ANSI text returned by [FromPCBoard].
+func PCBoard(b []byte) []Token {
+	return Tokenize(FromPCBoard(b))
+}
+
+// Avatar returns the tokens of the Avatar color text b.
+// The token offsets are positions in the ANSI text returned by [FromAvatar].
+func Avatar(b []byte) []Token {
+	return Tokenize(FromAvatar(b))
+}
+
+// FromPCBoard translates the PCBoard color text b to ANSI text.
+//
+// The @Xnn color codes use a hexadecimal DOS attribute of the background and
+// foreground colors. The @CLS@, @CLREOL@, @BEEP@ and @POS:nn@ macros are translated
+// to their escape sequences and control codes, while the other action macros, such
+// as @PAUSE@ and @MORE@, are removed. Macros that display BBS and user information,
+// such as @USER@, are kept as text.
+func FromPCBoard(b []byte) []byte {
+	const code = len("@Xnn")
+	out := make([]byte, 0, len(b))
+	for i := 0; i < len(b); {
+		c := b[i]
+		if c != '@' {
+			out = append(out, c)
+			i++
+			continue
+		}
+		if i+code <= len(b) && (b[i+1] == 'X' || b[i+1] == 'x') && isHex(b[i+2]) && isHex(b[i+3]) {
+			a, _ := strconv.ParseUint(string(b[i+2:i+4]), 16, 8)
+			out = append(out, dosSGR(uint8(a))...)
+			i += code
+			continue
+		}
+		name, n := macro(b[i:])
+		if n == 0 {
+			out = append(out, c)
+			i++
+			continue
+		}
+		seq, ok := pcbMacro(name)
+		if !ok {
+			out = append(out, b[i:i+n]...)
+		}
+		out = append(out, seq...)
+		i += n
+	}
+	return out
+}
+
+// macro returns the name and length of the PCBoard @ macro at the start of b,
+// or a zero length when b does not begin with a macro.
+func macro(b []byte) (string, int) {
+	const limit = 24
+	for j := 1; j < min(len(b), limit); j++ {
+		c := b[j]
+		switch {
+		case c == '@':
+			if j == 1 {
+				return "", 0
+			}
+			return string(b[1:j]), j + 1
+		case c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == ':', c == '_':
+			continue
+		default:
+			return "", 0
+		}
+	}
+	return "", 0
+}
+
+// pcbMacro returns the ANSI text of the PCBoard macro name,
+// or false when the macro is not an action.
+func pcbMacro(name string) ([]byte, bool) {
+	const pos = "POS:"
+	switch name {
+	case "CLS":
+		return []byte("\x1b[2J\x1b[1;1H"), true
+	case "CLREOL":
+		return []byte("\x1b[K"), true
+	case "BEEP":
+		return []byte{BEL}, true
+	case "PAUSE", "MORE", "HANGUP", "QON", "QOFF", "WAIT", "AUTOMORE", "POFF", "PON", "DELAY":
+		return nil, true
+	}
+	if len(name) > len(pos) && name[:len(pos)] == pos {
+		col, err := strconv.Atoi(name[len(pos):])
+		if err != nil {
+			return nil, false
+		}
+		return []byte("\x1b[" + strconv.Itoa(col) + "G"), true
+	}
+	return nil, false
+}
+
+// Avatar control codes.
+const (
+	avtClear  byte = 0x0c // ^L clears the screen
+	avtCmd    byte = 0x16 // ^V begins a command
+	avtRepeat byte = 0x19 // ^Y repeats a character
+)
+
+// maxRepeat is the maximum number of characters repeated by the Avatar text of a file,
+// which is enough to fill a screen of 80 columns and 65536 lines.
+const maxRepeat = 80 * 65536
+
+// FromAvatar translates the Avatar color text b, using the AVT/0 and AVT/0+
+// control codes, to ANSI text.
+//
+// The attribute, blink, cursor movement, clear to end of line, locate,
+// clear screen and character and pattern repeat commands are translated.
+// The AVT/0+ insert mode, scroll, area and delete commands are removed.
+// The repeat commands are limited to a total of 5,242,880 characters, and the
+// repeats beyond the limit are shortened or removed.
+//
+// The codes are described by the FidoNet FSC-0025 and FSC-0037 documents.
+func FromAvatar(b []byte) []byte {
+	out := make([]byte, 0, len(b))
+	remain := maxRepeat
+	for i := 0; i < len(b); {
+		switch b[i] {
+		case avtClear:
+			out = append(out, dosSGR(0x03)...)
+			out = append(out, "\x1b[2J\x1b[1;1H"...)
+			i++
+		case avtRepeat:
+			const args = 3
+			if i+args > len(b) {
+				return append(out, b[i:]...)
+			}
+			out = append(out, repeat(b[i+1:i+2], int(b[i+2]), &remain)...)
+			i += args
+		case avtCmd:
+			seq, n := avatarCmd(b[i:], &remain)
+			out = append(out, seq...)
+			i += n
+		default:
+			out = append(out, b[i])
+			i++
+		}
+	}
+	return out
+}
+
+// repeat returns s repeated n times, where the number of repeated characters
+// is limited to and subtracted from the characters that remain.
+func repeat(s []byte, n int, remain *int) []byte {
+	if len(s) == 0 {
+		return nil
+	}
+	n = min(n, *remain/len(s))
+	*remain -= n * len(s)
+	return bytes.Repeat(s, n)
+}
+
+// avatarCmd returns the ANSI text of the ^V command at the start of b,
+// and the number of bytes used by the command.
+// The characters of a pattern repeat are limited to the characters that remain.
+func avatarCmd(b []byte, remain *int) ([]byte, int) {
+	const (
+		attr     = 0x01
+		blink    = 0x02
+		up       = 0x03
+		down     = 0x04
+		left     = 0x05
+		right    = 0x06
+		clrEOL   = 0x07
+		locate   = 0x08
+		insert   = 0x09
+		scrollUp = 0x0a
+		scrollDn = 0x0b
+		clrArea  = 0x0c
+		initArea = 0x0d
+		delChar  = 0x0e
+		pattern  = 0x19
+	)
+	if len(b) < 2 {
+		return b, len(b)
+	}
+	// args reports whether the command has all of its n argument bytes
+	args := func(n int) bool {
+		return len(b) >= 2+n
+	}
+	switch b[1] {
+	case attr:
+		if !args(1) {
+			return nil, len(b)
+		}
+		return dosSGR(b[2]), 3
+	case blink:
+		return []byte("\x1b[5m"), 2
+	case up:
+		return []byte("\x1b[A"), 2
+	case down:
+		return []byte("\x1b[B"), 2
+	case left:
+		return []byte("\x1b[D"), 2
+	case right:
+		return []byte("\x1b[C"), 2
+	case clrEOL:
+		return []byte("\x1b[K"), 2
+	case locate:
+		if !args(2) {
+			return nil, len(b)
+		}
+		return []byte("\x1b[" + strconv.Itoa(int(b[2])) + ";" + strconv.Itoa(int(b[3])) + "H"), 4
+	case insert, delChar:
+		return nil, 2
+	case scrollUp, scrollDn:
+		return nil, min(len(b), 2+5)
+	case clrArea:
+		return nil, min(len(b), 2+3)
+	case initArea:
+		return nil, min(len(b), 2+4)
+	case pattern:
+		if !args(1) || !args(int(b[2])+2) {
+			return nil, len(b)
+		}
+		n := int(b[2])
+		return repeat(b[3:3+n], int(b[3+n]), remain), 2 + n + 2
+	}
+	return b[:2], 2
+}
+
+// dosSGR returns the SGR sequence of the DOS text mode attribute a,
+// where the high intensity foreground is bold and the high bit is blink.
+func dosSGR(a uint8) []byte {
+	const intense, blinking = 0x08, 0x80
+	// ansi returns the ANSI order color of the CGA color value i
+	ansi := func(i uint8) int {
+		return [...]int{0, 4, 2, 6, 1, 5, 3, 7}[i&7]
+	}
+	s := "\x1b[0"
+	if a&intense != 0 {
+		s += ";1"
+	}
+	if a&blinking != 0 {
+		s += ";5"
+	}
+	s += ";3" + strconv.Itoa(ansi(a)) + ";4" + strconv.Itoa(ansi(a>>4)) + "m"
+	return []byte(s)
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'A' && c <= 'F') || (c >= 'a' && c <= 'f')
+}
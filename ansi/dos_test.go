@@ -0,0 +1,98 @@
+package ansi_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bengarrett/sauce/ansi"
+)
+
+func TestFromPCBoard(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		b    string
+		want string
+	}{
+		{"text", "Hello", "Hello"},
+		{"color", "@X1FHi", "\x1b[0;1;37;44mHi"},
+		{"blink", "@X8c!", "\x1b[0;1;5;31;40m!"},
+		{"brown", "@X06A", "\x1b[0;33;40mA"},
+		{"not a code", "@XZZ @", "@XZZ @"},
+		{"email", "user@example.com", "user@example.com"},
+		{"cls", "@CLS@A", "\x1b[2J\x1b[1;1HA"},
+		{"pause", "A@PAUSE@B@MORE@", "AB"},
+		{"position", "@POS:40@X", "\x1b[40GX"},
+		{"user", "Hi @USER@", "Hi @USER@"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := string(ansi.FromPCBoard([]byte(tt.b))); got != tt.want {
+				t.Errorf("FromPCBoard() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromAvatar(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		b    string
+		want string
+	}{
+		{"text", "Hello", "Hello"},
+		{"attribute", "\x16\x01\x1fHi", "\x1b[0;1;37;44mHi"},
+		{"repeat", "\x19-\x05", "-----"},
+		{"clear", "\x0c", "\x1b[0;36;40m\x1b[2J\x1b[1;1H"},
+		{"cursor", "\x16\x03\x16\x04\x16\x05\x16\x06\x16\x07", "\x1b[A\x1b[B\x1b[D\x1b[C\x1b[K"},
+		{"blink", "\x16\x02", "\x1b[5m"},
+		{"locate", "\x16\x08\x05\x0aX", "\x1b[5;10HX"},
+		{"pattern", "\x16\x19\x02ab\x03", "ababab"},
+		{"scroll", "\x16\x0a\x01\x01\x01\x18\x50X", "X"},
+		{"truncated", "A\x19-", "A\x19-"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := string(ansi.FromAvatar([]byte(tt.b))); got != tt.want {
+				t.Errorf("FromAvatar() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromAvatar_Repeats(t *testing.T) {
+	t.Parallel()
+	// a pattern of 255 characters repeated 255 times expands to 65,025 characters
+	pattern := append(append([]byte{0x16, 0x19, 0xff}, bytes.Repeat([]byte("x"), 0xff)...), 0xff)
+	b := bytes.Repeat(pattern, 100)
+	b = append(b, bytes.Repeat([]byte{0x19, 'y', 0xff}, 100)...)
+	b = append(b, "end"...)
+	const limit = 80 * 65536
+	got := ansi.FromAvatar(b)
+	if want := limit + len("end"); len(got) != want {
+		t.Errorf("FromAvatar() length = %d, want %d", len(got), want)
+	}
+	if !bytes.HasSuffix(got, []byte("yend")) {
+		t.Errorf("FromAvatar() does not end with the remaining repeats and text")
+	}
+}
+
+func TestPCBoard(t *testing.T) {
+	t.Parallel()
+	toks := ansi.PCBoard([]byte("@X0EHi"))
+	const want = 2
+	if len(toks) != want {
+		t.Errorf("PCBoard() length = %d, want %d", len(toks), want)
+		return
+	}
+	if toks[0].Kind != ansi.SGR || toks[1].Text() != "Hi" {
+		t.Errorf("PCBoard() = %v, want a SGR and text", toks)
+	}
+	toks = ansi.Avatar([]byte("\x16\x01\x0eHi"))
+	if len(toks) != want || toks[0].Kind != ansi.SGR {
+		t.Errorf("Avatar() = %v, want a SGR and text", toks)
+	}
+}
@@ -0,0 +1,234 @@
+package sauce
+
+import (
+	"bytes"
+	"encoding/binary"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bengarrett/sauce/ansi"
+	"github.com/bengarrett/sauce/internal/layout"
+	"github.com/bengarrett/sauce/screen"
+)
+
+// Tagger fills the SAUCE record r with the metadata of the content b.
+// Taggers are used by [AutoTag] to read the formats of other packages,
+// such as the tracker module and bitmap image headers.
+type Tagger func(b []byte, r *Record)
+
+// TagOptions are the settings used by [AutoTag].
+type TagOptions struct {
+	// Patterns are the path patterns that are matched against the end of the
+	// file name, such as "{group}/{year}/{author}-{title}.ans".
+	// The placeholders {group}, {author}, {title} and {year} set the SAUCE fields,
+	// while any other placeholder, such as {ext}, matches text that is ignored.
+	// The first pattern that matches is used.
+	Patterns []string
+	// ModTime is the modification time of the file, used as the SAUCE date.
+	// When it is zero, the current time is used.
+	ModTime time.Time
+	// Taggers fill the record with the metadata of formats that are read by other packages.
+	// They are called in order after the content and file name are tagged.
+	Taggers []Tagger
+}
+
+// AutoTag returns a new SAUCE record for the file name with the content b.
+//
+// The DataType and FileType are inferred using [Sniff], and the FileSize is
+// the length of b without any existing SAUCE metadata. Text is played back
+// to set the TInfo character width and number of lines, and the non-blink flag
+// when it uses the blink attribute, while the XBin and RIPscrip headers set
+// their type information, flags and font.
+//
+// The Title is the name of the file without its extension, unless a
+// pattern of the options sets the Title, Author, Group or year.
+// The Date is the ModTime of the options, unless it is from a different year
+// to the pattern, in which case it is the 1st of January of that year.
+// The named file is not opened.
+func AutoTag(name string, b []byte, opts TagOptions) Record {
+	dt, ft, _ := Sniff(b)
+	b = Trim(b)
+	r := Record{
+		Data: layout.Datas{Type: dt},
+		File: layout.Files{Type: ft},
+	}
+	r.content(b)
+	base := filepath.Base(name)
+	r.Title = strings.TrimSpace(strings.ReplaceAll(strings.TrimSuffix(base, filepath.Ext(base)), "_", " "))
+	mod := opts.ModTime
+	for _, pattern := range opts.Patterns {
+		fields, ok := match(pattern, name)
+		if !ok {
+			continue
+		}
+		r.Title = value(fields["title"], r.Title)
+		r.Author = value(fields["author"], r.Author)
+		r.Group = value(fields["group"], r.Group)
+		if y, err := strconv.Atoi(fields["year"]); err == nil && y != mod.Year() {
+			mod = time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC)
+		}
+		break
+	}
+	if mod.IsZero() {
+		mod = time.Now()
+	}
+	r.Date.Time = mod
+	for _, tag := range opts.Taggers {
+		tag(b, &r)
+	}
+	return Decode(r.record(uint32(len(b)))) //nolint:gosec
+}
+
+// content sets the type information, flags and font of the record from the content b.
+func (r *Record) content(b []byte) {
+	const (
+		vga          = "IBM VGA"
+		ripW         = 640
+		ripH         = 350
+		ripC         = 16
+		xbinID       = "XBIN\x1a"
+		xbinLen      = 11
+		xbinNonBlink = 1 << 3
+		xbinFont     = 1 << 1
+	)
+	switch r.Data.Type {
+	case layout.Characters:
+		var s *screen.Screen
+		switch layout.Character(r.File.Type) {
+		case layout.ASCII:
+			// plain text has no fixed screen width, so it uses the widest line
+			columns, lines := measure(b)
+			r.Info.Info1.Value = uint16(min(columns, screen.MaxColumns)) //nolint:gosec
+			r.Info.Info2.Value = uint16(min(lines, 0xffff))              //nolint:gosec
+			r.Info.Font = vga
+			return
+		case layout.Ansi, layout.AnsiMation:
+			s = screen.Load(b, screen.Columns)
+		case layout.PCBoard:
+			s = screen.New(screen.Columns)
+			s.Play(ansi.PCBoard(b))
+		case layout.Avatar:
+			s = screen.New(screen.Columns)
+			s.Play(ansi.Avatar(b))
+		case layout.RipScript:
+			r.Info.Info1.Value, r.Info.Info2.Value, r.Info.Info3.Value = ripW, ripH, ripC
+			return
+		case layout.TundraDraw:
+			r.Info.Info1.Value = screen.Columns
+			return
+		default:
+			return
+		}
+		st := s.Stats()
+		r.Info.Info1.Value = uint16(st.Columns)            //nolint:gosec
+		r.Info.Info2.Value = uint16(min(st.Lines, 0xffff)) //nolint:gosec
+		r.Info.Flags.SetNonBlink(st.Blink)
+		r.Info.Font = vga
+	case layout.XBins:
+		if len(b) < xbinLen || !bytes.HasPrefix(b, []byte(xbinID)) {
+			return
+		}
+		r.Info.Info1.Value = binary.LittleEndian.Uint16(b[5:])
+		r.Info.Info2.Value = binary.LittleEndian.Uint16(b[7:])
+		if b[10]&xbinNonBlink != 0 {
+			r.Info.Flags.SetNonBlink(true)
+		}
+		if b[10]&xbinFont == 0 {
+			r.Info.Font = vga
+		}
+	case layout.BinaryTexts:
+		// the file type of a binary text is half its character width, which is usually 160
+		const width = 160
+		r.File.Type = width / 2
+	default:
+	}
+}
+
+// measure returns the width of the widest line and the number of lines of the plain text b,
+// without any trailing blank lines. The text ends at the first SUB end-of-file marker.
+func measure(b []byte) (int, int) {
+	const tabStop = 8
+	columns, lines, x, y := 0, 0, 0, 0
+	for _, c := range b {
+		switch c {
+		case ansi.CR:
+			x = 0
+		case ansi.LF:
+			x = 0
+			y++
+		case ansi.BS:
+			x = max(0, x-1)
+		case ansi.TAB:
+			x = (x/tabStop + 1) * tabStop
+		case ansi.SUB:
+			return columns, lines
+		default:
+			x++
+			columns = max(columns, x)
+			lines = max(lines, y+1)
+		}
+	}
+	return columns, lines
+}
+
+// placeholder matches the {name} placeholders of a path pattern.
+var placeholder = regexp.MustCompile(`\{([a-z]+)\}`)
+
+// template is a compiled path pattern, where re is nil when the pattern is invalid.
+type template struct {
+	re   *regexp.Regexp
+	keys []string
+}
+
+// compile returns the compiled path pattern.
+func compile(pattern string) *template {
+	var expr strings.Builder
+	expr.WriteString(`(?:^|/)`)
+	last := 0
+	t := &template{keys: []string{}}
+	for _, loc := range placeholder.FindAllStringSubmatchIndex(pattern, -1) {
+		expr.WriteString(regexp.QuoteMeta(pattern[last:loc[0]]))
+		key := pattern[loc[2]:loc[3]]
+		if key == "year" {
+			expr.WriteString(`(\d{4})`)
+		} else {
+			expr.WriteString(`([^/]+?)`)
+		}
+		t.keys = append(t.keys, key)
+		last = loc[1]
+	}
+	expr.WriteString(regexp.QuoteMeta(pattern[last:]) + `$`)
+	if re, err := regexp.Compile(expr.String()); err == nil {
+		t.re = re
+	}
+	return t
+}
+
+// match returns the placeholder values of the path pattern when it matches the end of name.
+func match(pattern, name string) (map[string]string, bool) {
+	t := compile(pattern)
+	if t.re == nil {
+		return nil, false
+	}
+	m := t.re.FindStringSubmatch(path.Clean(filepath.ToSlash(name)))
+	if m == nil {
+		return nil, false
+	}
+	fields := make(map[string]string, len(t.keys))
+	for i, key := range t.keys {
+		fields[key] = strings.TrimSpace(strings.ReplaceAll(m[i+1], "_", " "))
+	}
+	return fields, true
+}
+
+// value returns s, or the fallback when s is empty.
+func value(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
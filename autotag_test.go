@@ -0,0 +1,118 @@
+package sauce_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+func TestAutoTag(t *testing.T) {
+	t.Parallel()
+	mod := time.Date(2001, time.March, 4, 12, 0, 0, 0, time.UTC)
+	opts := sauce.TagOptions{
+		Patterns: []string{"{group}/{year}/{author}-{title}.ans"},
+		ModTime:  mod,
+	}
+	ans := []byte("\x1b[1;31mhello\r\nworld\r\n\x1b[0m")
+	long := []byte(strings.Repeat("x\n", 20000) + strings.Repeat("y", 4000))
+	tests := []struct {
+		name   string
+		file   string
+		b      []byte
+		title  string
+		author string
+		group  string
+		date   string
+		data   layout.TypeOfData
+		width  uint16
+		lines  uint16
+	}{
+		{"pattern", "/pub/art/ACiD/1996/Somms-Blue_Skies.ans", ans, "Blue Skies", "Somms", "ACiD", "19960101",
+			layout.Characters, 5, 2},
+		{"same year", "iCE/2001/Ion-Lost.ans", ans, "Lost", "Ion", "iCE", "20010304", layout.Characters, 5, 2},
+		{"no match", "misc/Read_Me.txt", []byte("abc\r\nlonger line"), "Read Me", "", "", "20010304",
+			layout.Characters, 11, 2},
+		{"tabs", "tabs.txt", []byte("a\tb\r\n\r\n\x1amore"), "tabs", "", "", "20010304", layout.Characters, 9, 1},
+		{"long text", "long.txt", long, "long", "", "", "20010304", layout.Characters, 4000, 20001},
+		{"rip", "art.rip", []byte("!|c0F|L00001010"), "art", "", "", "20010304", layout.Characters, 640, 350},
+		{"binary", "data.bin", []byte{0, 1, 2, 3}, "data", "", "", "20010304", layout.Nones, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := sauce.AutoTag(tt.file, tt.b, opts)
+			if !r.Valid() {
+				t.Fatalf("AutoTag() is not a valid record")
+			}
+			if r.Title != tt.title || r.Author != tt.author || r.Group != tt.group {
+				t.Errorf("AutoTag() = %q, %q, %q, want %q, %q, %q",
+					r.Title, r.Author, r.Group, tt.title, tt.author, tt.group)
+			}
+			if r.Date.Value != tt.date {
+				t.Errorf("AutoTag() date = %q, want %q", r.Date.Value, tt.date)
+			}
+			if r.Data.Type != tt.data || r.Info.Info1.Value != tt.width || r.Info.Info2.Value != tt.lines {
+				t.Errorf("AutoTag() = %s, %d, %d, want %s, %d, %d",
+					r.Data.Type, r.Info.Info1.Value, r.Info.Info2.Value, tt.data, tt.width, tt.lines)
+			}
+			if want := uint32(len(tt.b)); r.FileSize.Bytes != want {
+				t.Errorf("AutoTag() file size = %d, want %d", r.FileSize.Bytes, want)
+			}
+		})
+	}
+}
+
+func TestAutoTag_Taggers(t *testing.T) {
+	t.Parallel()
+	xbin := []byte("XBIN\x1a\x50\x00\x19\x00\x10\x08")
+	opts := sauce.TagOptions{
+		ModTime: time.Date(1997, time.May, 1, 0, 0, 0, 0, time.UTC),
+		Taggers: []sauce.Tagger{func(_ []byte, r *sauce.Record) { r.Author = "tagger" }},
+	}
+	r := sauce.AutoTag("logo.xb", xbin, opts)
+	if r.Author != "tagger" {
+		t.Errorf("AutoTag() author = %q, want %q", r.Author, "tagger")
+	}
+	if r.Data.Type != layout.XBins || r.Info.Info1.Value != 80 || r.Info.Info2.Value != 25 {
+		t.Errorf("AutoTag() = %s, %d, %d", r.Data.Type, r.Info.Info1.Value, r.Info.Info2.Value)
+	}
+	if r.Info.Font != "IBM VGA" || !r.Info.Flags.NonBlink() {
+		t.Errorf("AutoTag() font = %q, non-blink = %v", r.Info.Font, r.Info.Flags.NonBlink())
+	}
+}
+
+func TestAutoTag_NonBlink(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		b    string
+		want bool
+	}{
+		{"blink", "\x1b[5;44mX\x1b[0m", true},
+		{"no blink", "\x1b[1;44mX\x1b[0m", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := sauce.AutoTag("ice.ans", []byte(tt.b), sauce.TagOptions{})
+			if got := r.Info.Flags.NonBlink(); got != tt.want {
+				t.Errorf("AutoTag() non-blink = %v, want %v", got, tt.want)
+			}
+			if r.Info.Info1.Value != 1 {
+				t.Errorf("AutoTag() width = %d, want 1", r.Info.Info1.Value)
+			}
+		})
+	}
+}
+
+func TestAutoTag_ModTime(t *testing.T) {
+	t.Parallel()
+	// the named file is not read, so a zero modification time is the current time
+	r := sauce.AutoTag("autotag_test.go", []byte("hello"), sauce.TagOptions{})
+	if got, want := r.Date.Time.Year(), time.Now().Year(); got != want {
+		t.Errorf("AutoTag() date = %d, want %d", got, want)
+	}
+}
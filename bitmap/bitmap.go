@@ -0,0 +1,150 @@
+// Package bitmap reads the pixel dimensions and color depth of bitmap images.
+//
+// The SAUCE type information of the bitmap data type is the pixel width,
+// pixel height and pixel depth of the image, which can be compared to the
+// headers of the GIF, PCX, LBM, TGA, FLI, FLC, BMP, PNG and JPEG files.
+//
+// See http://www.acid.org/info/sauce/sauce.htm#FileType
+package bitmap
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+var (
+	ErrFormat   = errors.New("unknown or unsupported image format")
+	ErrShort    = errors.New("image header is too short")
+	ErrHeader   = errors.New("invalid image header")
+	ErrDataType = errors.New("record data type is not bitmap")
+	ErrFileType = errors.New("record file type does not match the image")
+	ErrWidth    = errors.New("record pixel width does not match the image")
+	ErrHeight   = errors.New("record pixel height does not match the image")
+	ErrDepth    = errors.New("record pixel depth does not match the image")
+)
+
+// Image is the header information of a bitmap image.
+type Image struct {
+	Type   sauce.FileType // type is the SAUCE bitmap file type
+	Width  int            // width of the image in pixels
+	Height int            // height of the image in pixels
+	Depth  int            // depth is the number of bits per pixel
+}
+
+// Parse returns the header information of the image b.
+// The format is identified by the signatures in the image using [sauce.Sniff].
+// Targa images without the version 2 footer cannot be identified,
+// and should use [Decode] with the [sauce.BitmapTga] file type.
+func Parse(b []byte) (*Image, error) {
+	dt, ft, _ := sauce.Sniff(b)
+	if dt != sauce.DataBitmap {
+		return nil, ErrFormat
+	}
+	return Decode(b, ft)
+}
+
+// Decode returns the header information of the image b of the file type ft.
+func Decode(b []byte, ft sauce.FileType) (*Image, error) {
+	var read func([]byte) (*Image, error)
+	switch ft {
+	case sauce.BitmapGif:
+		read = gif
+	case sauce.BitmapPcx:
+		read = pcx
+	case sauce.BitmapLbm:
+		read = lbm
+	case sauce.BitmapTga:
+		read = tga
+	case sauce.BitmapFli, sauce.BitmapFlc:
+		read = fli
+	case sauce.BitmapBmp:
+		read = bmp
+	case sauce.BitmapPng:
+		read = png
+	case sauce.BitmapJpg:
+		read = jpeg
+	case sauce.BitmapGl, sauce.BitmapDl, sauce.BitmapWpg, sauce.BitmapMpg, sauce.BitmapAvi:
+		return nil, fmt.Errorf("%w: %s", ErrFormat, layout.Bitmap(ft))
+	default:
+		return nil, ErrFormat
+	}
+	img, err := read(b)
+	if err != nil {
+		return nil, err
+	}
+	if ft != sauce.BitmapFli && ft != sauce.BitmapFlc {
+		img.Type = ft
+	}
+	return img, nil
+}
+
+// Fill sets the data type, file type and the pixel width, height and depth
+// type information of the SAUCE record r to the header information of the image.
+func (img *Image) Fill(r *sauce.Record) {
+	r.Data = layout.Datas{Type: layout.Bitmaps, Name: layout.Bitmaps.String()}
+	r.File = layout.Files{Type: img.Type, Name: layout.Bitmap(img.Type).String()}
+	r.Info.Info1 = layout.Info{Value: clamp(img.Width), Info: "pixel width"}
+	r.Info.Info2 = layout.Info{Value: clamp(img.Height), Info: "pixel height"}
+	r.Info.Info3 = layout.Info{Value: clamp(img.Depth), Info: "pixel depth"}
+}
+
+// Tag fills the SAUCE record r with the header information of the image b,
+// and is a [sauce.Tagger] for use with [sauce.AutoTag].
+// The record is unchanged when b is not a supported image.
+func Tag(b []byte, r *sauce.Record) {
+	img, err := Parse(b)
+	if err != nil {
+		return
+	}
+	img.Fill(r)
+}
+
+// Check returns an error when the SAUCE record r is not a bitmap data type,
+// its file type is not the image format, or its type information does not
+// match the pixel width, height and depth of the image.
+func (img *Image) Check(r *sauce.Record) error {
+	if r == nil || r.Data.Type != sauce.DataBitmap {
+		return ErrDataType
+	}
+	var errs []error
+	if r.File.Type != img.Type {
+		errs = append(errs, fmt.Errorf("%w: %s, want %s", ErrFileType, r.File.Name, layout.Bitmap(img.Type)))
+	}
+	if v := int(r.Info.Info1.Value); v != img.Width {
+		errs = append(errs, fmt.Errorf("%w: %d, want %d", ErrWidth, v, img.Width))
+	}
+	if v := int(r.Info.Info2.Value); v != img.Height {
+		errs = append(errs, fmt.Errorf("%w: %d, want %d", ErrHeight, v, img.Height))
+	}
+	if v := int(r.Info.Info3.Value); v != img.Depth {
+		errs = append(errs, fmt.Errorf("%w: %d, want %d", ErrDepth, v, img.Depth))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate returns an error when the image b cannot be read or does not match
+// the type information of the SAUCE record r. Images that cannot be identified
+// by their signatures are read using the file type of the record.
+func Validate(b []byte, r *sauce.Record) error {
+	b = sauce.Trim(b)
+	if r == nil || r.Data.Type != sauce.DataBitmap {
+		return ErrDataType
+	}
+	img, err := Parse(b)
+	if errors.Is(err, ErrFormat) {
+		img, err = Decode(b, r.File.Type)
+	}
+	if err != nil {
+		return err
+	}
+	return img.Check(r)
+}
+
+// clamp returns the value v as a SAUCE type information value.
+func clamp(v int) uint16 {
+	return uint16(min(max(v, 0), math.MaxUint16)) //nolint:gosec
+}
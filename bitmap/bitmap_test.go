@@ -0,0 +1,176 @@
+package bitmap_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/bitmap"
+)
+
+func encode(t *testing.T, enc func(*bytes.Buffer, image.Image) error) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 64, 32))
+	var buf bytes.Buffer
+	if err := enc(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func bmpFile() []byte {
+	b := make([]byte, 54)
+	copy(b, "BM")
+	le := binary.LittleEndian
+	le.PutUint32(b[14:], 40)
+	le.PutUint32(b[18:], 320)
+	le.PutUint32(b[22:], uint32(0x100000000-200)) // top-down
+	le.PutUint16(b[28:], 8)
+	return b
+}
+
+func pcxFile() []byte {
+	b := make([]byte, 128)
+	b[0], b[1], b[2], b[3] = 0x0a, 5, 1, 1
+	binary.LittleEndian.PutUint16(b[8:], 639)
+	binary.LittleEndian.PutUint16(b[10:], 349)
+	b[65] = 4
+	return b
+}
+
+func lbmFile() []byte {
+	b := []byte("FORM\x00\x00\x00\x00ILBMANNO\x00\x00\x00\x03abc\x00BMHD\x00\x00\x00\x14")
+	h := make([]byte, 20)
+	binary.BigEndian.PutUint16(h, 320)
+	binary.BigEndian.PutUint16(h[2:], 200)
+	h[8] = 5
+	return append(b, h...)
+}
+
+func fliFile() []byte {
+	b := make([]byte, 128)
+	binary.LittleEndian.PutUint16(b[4:], 0xaf12)
+	binary.LittleEndian.PutUint16(b[8:], 640)
+	binary.LittleEndian.PutUint16(b[10:], 480)
+	binary.LittleEndian.PutUint16(b[12:], 8)
+	return b
+}
+
+func tgaFile() []byte {
+	b := make([]byte, 18)
+	b[2] = 2
+	binary.LittleEndian.PutUint16(b[12:], 100)
+	binary.LittleEndian.PutUint16(b[14:], 50)
+	b[16] = 24
+	return append(b, "\x00\x00\x00\x00\x00\x00\x00\x00TRUEVISION-XFILE.\x00"...)
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	gray := func(buf *bytes.Buffer, _ image.Image) error {
+		return jpeg.Encode(buf, image.NewGray(image.Rect(0, 0, 16, 8)), nil)
+	}
+	pal := func(buf *bytes.Buffer, img image.Image) error {
+		return gif.Encode(buf, img, &gif.Options{NumColors: 16})
+	}
+	tests := []struct {
+		name string
+		b    []byte
+		want bitmap.Image
+	}{
+		{"gif", encode(t, pal), bitmap.Image{Type: sauce.BitmapGif, Width: 64, Height: 32, Depth: 4}},
+		{"png", encode(t, func(b *bytes.Buffer, i image.Image) error { return png.Encode(b, i) }),
+			bitmap.Image{Type: sauce.BitmapPng, Width: 64, Height: 32, Depth: 32}},
+		{"jpeg", encode(t, func(b *bytes.Buffer, i image.Image) error { return jpeg.Encode(b, i, nil) }),
+			bitmap.Image{Type: sauce.BitmapJpg, Width: 64, Height: 32, Depth: 24}},
+		{"jpeg gray", encode(t, gray), bitmap.Image{Type: sauce.BitmapJpg, Width: 16, Height: 8, Depth: 8}},
+		{"bmp", bmpFile(), bitmap.Image{Type: sauce.BitmapBmp, Width: 320, Height: 200, Depth: 8}},
+		{"pcx", pcxFile(), bitmap.Image{Type: sauce.BitmapPcx, Width: 640, Height: 350, Depth: 4}},
+		{"lbm", lbmFile(), bitmap.Image{Type: sauce.BitmapLbm, Width: 320, Height: 200, Depth: 5}},
+		{"flc", fliFile(), bitmap.Image{Type: sauce.BitmapFlc, Width: 640, Height: 480, Depth: 8}},
+		{"tga", tgaFile(), bitmap.Image{Type: sauce.BitmapTga, Width: 100, Height: 50, Depth: 24}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := bitmap.Parse(tt.b)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("Parse() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	t.Parallel()
+	if _, err := bitmap.Parse([]byte("hello world")); !errors.Is(err, bitmap.ErrFormat) {
+		t.Errorf("Parse() error = %v, want %v", err, bitmap.ErrFormat)
+	}
+	if _, err := bitmap.Decode([]byte("GIF89a"), sauce.BitmapGif); !errors.Is(err, bitmap.ErrShort) {
+		t.Errorf("Decode() error = %v, want %v", err, bitmap.ErrShort)
+	}
+	if _, err := bitmap.Decode(nil, sauce.BitmapAvi); !errors.Is(err, bitmap.ErrFormat) {
+		t.Errorf("Decode() error = %v, want %v", err, bitmap.ErrFormat)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	r := sauce.Record{}
+	r.Data.Type = sauce.DataBitmap
+	r.File.Type = sauce.BitmapPcx
+	r.Info.Info1.Value, r.Info.Info2.Value, r.Info.Info3.Value = 640, 350, 4
+	if err := bitmap.Validate(pcxFile(), &r); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	r.Info.Info2.Value, r.Info.Info3.Value = 480, 8
+	err := bitmap.Validate(pcxFile(), &r)
+	if !errors.Is(err, bitmap.ErrHeight) || !errors.Is(err, bitmap.ErrDepth) || errors.Is(err, bitmap.ErrWidth) {
+		t.Errorf("Validate() error = %v, want %v and %v", err, bitmap.ErrHeight, bitmap.ErrDepth)
+	}
+	r.File.Type = sauce.BitmapTga
+	if err := bitmap.Validate(bmpFile(), &r); !errors.Is(err, bitmap.ErrFileType) {
+		t.Errorf("Validate() error = %v, want %v", err, bitmap.ErrFileType)
+	}
+	r.Data.Type = sauce.DataCharacter
+	if err := bitmap.Validate(pcxFile(), &r); !errors.Is(err, bitmap.ErrDataType) {
+		t.Errorf("Validate() error = %v, want %v", err, bitmap.ErrDataType)
+	}
+}
+
+func ExampleParse() {
+	var buf bytes.Buffer
+	img := image.NewPaletted(image.Rect(0, 0, 320, 200), color.Palette{color.Black, color.White})
+	if err := png.Encode(&buf, img); err != nil {
+		fmt.Println(err)
+		return
+	}
+	info, err := bitmap.Parse(buf.Bytes())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	var r sauce.Record
+	info.Fill(&r)
+	fmt.Printf("%s, %dx%d, %d-bit\n", r.File.Name, info.Width, info.Height, info.Depth)
+	// Output: PNG image, 320x200, 1-bit
+}
+
+func TestTag(t *testing.T) {
+	t.Parallel()
+	r := sauce.AutoTag("scene.pcx", pcxFile(), sauce.TagOptions{Taggers: []sauce.Tagger{bitmap.Tag}})
+	if err := bitmap.Validate(pcxFile(), &r); err != nil {
+		t.Errorf("AutoTag() with Tag error = %v", err)
+	}
+}
@@ -0,0 +1,210 @@
+package bitmap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image/color"
+	stdjpeg "image/jpeg"
+
+	"github.com/bengarrett/sauce"
+)
+
+// gif returns the logical screen size and the color table depth of a GIF image.
+func gif(b []byte) (*Image, error) {
+	const (
+		header  = 13
+		packed  = 10
+		global  = 0x80 // global is the global color table flag
+		size    = 0x07 // size is the bits of the global color table size, less one
+		res     = 0x70 // res is the bits of the color resolution, less one
+		resBits = 4
+	)
+	if len(b) < header {
+		return nil, fmt.Errorf("%w: gif", ErrShort)
+	}
+	depth := int(b[packed]&res)>>resBits + 1
+	if b[packed]&global != 0 {
+		depth = int(b[packed]&size) + 1
+	}
+	return &Image{
+		Width:  int(binary.LittleEndian.Uint16(b[6:])),
+		Height: int(binary.LittleEndian.Uint16(b[8:])),
+		Depth:  depth,
+	}, nil
+}
+
+// pcx returns the window size and the depth of the color planes of a ZSoft Paintbrush image.
+func pcx(b []byte) (*Image, error) {
+	const (
+		header = 128
+		bpp    = 3
+		planes = 65
+	)
+	if len(b) < header {
+		return nil, fmt.Errorf("%w: pcx", ErrShort)
+	}
+	le := binary.LittleEndian
+	xmin, ymin := int(le.Uint16(b[4:])), int(le.Uint16(b[6:]))
+	xmax, ymax := int(le.Uint16(b[8:])), int(le.Uint16(b[10:]))
+	if xmax < xmin || ymax < ymin {
+		return nil, fmt.Errorf("%w: pcx window", ErrHeader)
+	}
+	return &Image{
+		Width:  xmax - xmin + 1,
+		Height: ymax - ymin + 1,
+		Depth:  int(b[bpp]) * int(b[planes]),
+	}, nil
+}
+
+// lbm returns the bitmap header of an IFF ILBM or PBM DeluxePaint image.
+func lbm(b []byte) (*Image, error) {
+	const (
+		form   = 12 // form is the size of the FORM header
+		chunk  = 8  // chunk is the size of a chunk header
+		bmhd   = 20 // bmhd is the size of the bitmap header chunk
+		planes = 8
+	)
+	if len(b) < form || !bytes.HasPrefix(b, []byte("FORM")) {
+		return nil, fmt.Errorf("%w: lbm", ErrShort)
+	}
+	be := binary.BigEndian
+	for i := form; i+chunk <= len(b); {
+		id, size := string(b[i:i+4]), int(be.Uint32(b[i+4:]))
+		i += chunk
+		if id != "BMHD" {
+			// chunks are padded to an even length
+			i += size + size%2
+			continue
+		}
+		if size < bmhd || i+bmhd > len(b) {
+			break
+		}
+		return &Image{
+			Width:  int(be.Uint16(b[i:])),
+			Height: int(be.Uint16(b[i+2:])),
+			Depth:  int(b[i+planes]),
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: lbm bitmap header", ErrShort)
+}
+
+// tga returns the image specification of a Targa image.
+func tga(b []byte) (*Image, error) {
+	const (
+		header = 18
+		depth  = 16
+	)
+	if len(b) < header {
+		return nil, fmt.Errorf("%w: tga", ErrShort)
+	}
+	return &Image{
+		Width:  int(binary.LittleEndian.Uint16(b[12:])),
+		Height: int(binary.LittleEndian.Uint16(b[14:])),
+		Depth:  int(b[depth]),
+	}, nil
+}
+
+// fli returns the frame size and depth of an Autodesk Animator FLI or FLC animation.
+func fli(b []byte) (*Image, error) {
+	const (
+		header = 128
+		magic  = 4
+		fliID  = 0xaf11
+		flcID  = 0xaf12
+	)
+	if len(b) < header {
+		return nil, fmt.Errorf("%w: fli", ErrShort)
+	}
+	le := binary.LittleEndian
+	img := &Image{
+		Width:  int(le.Uint16(b[8:])),
+		Height: int(le.Uint16(b[10:])),
+		Depth:  int(le.Uint16(b[12:])),
+	}
+	switch le.Uint16(b[magic:]) {
+	case fliID:
+		img.Type = sauce.BitmapFli
+	case flcID:
+		img.Type = sauce.BitmapFlc
+	default:
+		return nil, fmt.Errorf("%w: fli magic", ErrHeader)
+	}
+	return img, nil
+}
+
+// bmp returns the DIB header information of a Windows or OS/2 bitmap.
+func bmp(b []byte) (*Image, error) {
+	const (
+		dib  = 14
+		core = 12 // core is the size of the OS/2 1.x bitmap core header
+	)
+	if len(b) < dib+core {
+		return nil, fmt.Errorf("%w: bmp", ErrShort)
+	}
+	le := binary.LittleEndian
+	if le.Uint32(b[dib:]) == core {
+		return &Image{
+			Width:  int(le.Uint16(b[18:])),
+			Height: int(le.Uint16(b[20:])),
+			Depth:  int(le.Uint16(b[24:])),
+		}, nil
+	}
+	const info = 16 // info is the minimum size of the bitmap info header
+	if len(b) < dib+info {
+		return nil, fmt.Errorf("%w: bmp", ErrShort)
+	}
+	// a negative height is a top-down bitmap
+	height := int(int32(le.Uint32(b[22:]))) //nolint:gosec
+	return &Image{
+		Width:  int(int32(le.Uint32(b[18:]))), //nolint:gosec
+		Height: max(height, -height),
+		Depth:  int(le.Uint16(b[28:])),
+	}, nil
+}
+
+// png returns the image header chunk of a PNG image, where the depth is
+// the bit depth of all the channels of the color type.
+func png(b []byte) (*Image, error) {
+	const (
+		ihdr  = 8
+		data  = 16
+		depth = 24
+		ctype = 25
+	)
+	if len(b) <= ctype || string(b[ihdr+4:data]) != "IHDR" {
+		return nil, fmt.Errorf("%w: png", ErrShort)
+	}
+	// the number of channels of the grayscale, RGB, indexed, grayscale and alpha, and RGBA color types
+	channels := map[byte]int{0: 1, 2: 3, 3: 1, 4: 2, 6: 4}
+	n, ok := channels[b[ctype]]
+	if !ok {
+		return nil, fmt.Errorf("%w: png color type %d", ErrHeader, b[ctype])
+	}
+	be := binary.BigEndian
+	return &Image{
+		Width:  int(be.Uint32(b[data:])),
+		Height: int(be.Uint32(b[data+4:])),
+		Depth:  int(b[depth]) * n,
+	}, nil
+}
+
+// jpeg returns the frame size of a JPEG image, where the depth is
+// 8 bits for each component of the color model.
+func jpeg(b []byte) (*Image, error) {
+	cfg, err := stdjpeg.DecodeConfig(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("%w: jpeg: %w", ErrHeader, err)
+	}
+	const gray, ycbcr, cmyk = 8, 24, 32
+	img := &Image{Width: cfg.Width, Height: cfg.Height}
+	switch cfg.ColorModel {
+	case color.GrayModel:
+		img.Depth = gray
+	case color.CMYKModel:
+		img.Depth = cmyk
+	default:
+		img.Depth = ycbcr
+	}
+	return img, nil
+}
@@ -0,0 +1,343 @@
+// Package catalog keeps an index of the SAUCE records of a large collection of files.
+//
+// A catalog is stored in a local file of JSON lines, one entry for each file,
+// that is gzip compressed when the name ends with ".gz". Each entry is keyed by
+// the path, size, modification time and SHA-256 hash of a file, so a rescan of the
+// collection only reads the files that have changed, and only decodes the files
+// whose content has changed. Once scanned, the catalog can be queried without
+// opening the original files.
+package catalog
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/walk"
+)
+
+// Version is the format version of the catalog file.
+const Version = 1
+
+var (
+	ErrVersion = errors.New("unsupported catalog version")
+	ErrHeader  = errors.New("catalog file has no header")
+)
+
+// Entry is a file of the catalog.
+//
+// The Record is nil when the file has no SAUCE metadata. As the record is stored
+// as JSON, its Index fields and Desc description are not kept in the catalog.
+type Entry struct {
+	Path    string        `json:"path"`             // path of the file, relative to the root of the scan
+	Size    int64         `json:"size"`             // size of the file in bytes, including the SAUCE metadata
+	ModTime time.Time     `json:"modTime"`          // modification time of the file
+	Hash    string        `json:"sha256"`           // hash is the hexadecimal SHA-256 checksum of the file
+	Record  *sauce.Record `json:"record,omitempty"` // record of the file, or nil when it is not tagged
+}
+
+// Stats are the number of files found by a scan.
+type Stats struct {
+	Added     int // added are the new files
+	Updated   int // updated are the files with new content
+	Touched   int // touched are the files with a new modification time but the same content
+	Unchanged int // unchanged are the files with the same size and modification time
+	Removed   int // removed are the files that no longer exist
+}
+
+// Total returns the number of files in the catalog after the scan.
+func (s Stats) Total() int {
+	return s.Added + s.Updated + s.Touched + s.Unchanged
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("%d files: %d added, %d updated, %d touched, %d unchanged, %d removed",
+		s.Total(), s.Added, s.Updated, s.Touched, s.Unchanged, s.Removed)
+}
+
+// Catalog is an index of files and their SAUCE records.
+// A catalog is safe to query from multiple goroutines, but not while it is being scanned.
+type Catalog struct {
+	entries map[string]*Entry
+}
+
+// header is the first line of a catalog file.
+type header struct {
+	Version int       `json:"version"`
+	Saved   time.Time `json:"saved"`
+	Count   int       `json:"count"`
+}
+
+// New returns an empty catalog.
+func New() *Catalog {
+	return &Catalog{entries: map[string]*Entry{}}
+}
+
+// Open returns the catalog stored in the named file,
+// or an empty catalog when the file does not exist.
+func Open(name string) (*Catalog, error) {
+	f, err := os.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open catalog: %w", err)
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if compressed(name) {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("open catalog: %w", err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+	c, err := Read(r)
+	if err != nil {
+		return nil, fmt.Errorf("open catalog %s: %w", name, err)
+	}
+	return c, nil
+}
+
+// Read returns the catalog of JSON lines read from r.
+func Read(r io.Reader) (*Catalog, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	var h header
+	if err := dec.Decode(&h); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrHeader
+		}
+		return nil, fmt.Errorf("read catalog header: %w", err)
+	}
+	if h.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrVersion, h.Version)
+	}
+	c := New()
+	for {
+		e := &Entry{}
+		err := dec.Decode(e)
+		if errors.Is(err, io.EOF) {
+			return c, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read catalog entry %d: %w", len(c.entries)+1, err)
+		}
+		c.entries[e.Path] = e
+	}
+}
+
+// Save stores the catalog in the named file, which is gzip compressed when the name
+// ends with ".gz". The file is replaced once the catalog is written,
+// so an existing catalog is kept when an error occurs.
+func (c *Catalog) Save(name string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return fmt.Errorf("save catalog: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := c.save(tmp, compressed(name)); err != nil {
+		tmp.Close()
+		return fmt.Errorf("save catalog: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("save catalog: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return fmt.Errorf("save catalog: %w", err)
+	}
+	return nil
+}
+
+func (c *Catalog) save(f *os.File, compress bool) error {
+	if !compress {
+		return c.Write(f)
+	}
+	zw := gzip.NewWriter(f)
+	if err := c.Write(zw); err != nil {
+		return err
+	}
+	return zw.Close() //nolint:wrapcheck
+}
+
+// Write writes the catalog to w as JSON lines, a header followed by the entries ordered by path.
+func (c *Catalog) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	if err := enc.Encode(header{Version: Version, Saved: time.Now().UTC(), Count: c.Len()}); err != nil {
+		return fmt.Errorf("write catalog header: %w", err)
+	}
+	for _, e := range c.Entries() {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("write catalog entry %s: %w", e.Path, err)
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("write catalog: %w", err)
+	}
+	return nil
+}
+
+// Len returns the number of files in the catalog.
+func (c *Catalog) Len() int {
+	return len(c.entries)
+}
+
+// Entries returns the files of the catalog ordered by path.
+func (c *Catalog) Entries() []Entry {
+	entries := make([]Entry, 0, len(c.entries))
+	for _, e := range c.entries {
+		entries = append(entries, *e)
+	}
+	slices.SortFunc(entries, func(a, b Entry) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return entries
+}
+
+// Lookup returns the file of the catalog with the path.
+func (c *Catalog) Lookup(path string) (Entry, bool) {
+	e, ok := c.entries[path]
+	if !ok {
+		return Entry{}, false
+	}
+	return *e, true
+}
+
+// Query returns the files of the catalog, ordered by path, where match returns true.
+func (c *Catalog) Query(match func(Entry) bool) []Entry {
+	return slices.DeleteFunc(c.Entries(), func(e Entry) bool {
+		return !match(e)
+	})
+}
+
+// Scan updates the catalog with the files of fsys.
+//
+// A file with the same size and modification time as its entry is unchanged and is not opened.
+// Other files are read to find their SHA-256 hash, and only the files that are new or
+// whose hash has changed are decoded. The entries of the files that no longer exist are removed.
+// Hidden files and directories, whose names begin with a dot, are skipped.
+//
+// The files that cannot be read are kept in the catalog, and their errors are returned
+// joined by [errors.Join] after the rest of the files have been scanned.
+func (c *Catalog) Scan(fsys fs.FS) (Stats, error) {
+	var st Stats
+	seen := make(map[string]bool, len(c.entries))
+	changed := []fs.FileInfo{}
+	paths := []string{}
+	err := walk.Files(fsys, func(name string, d fs.DirEntry) error {
+		info, err := d.Info()
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		seen[name] = true
+		if e, ok := c.entries[name]; ok && e.Size == info.Size() && e.ModTime.Equal(info.ModTime()) {
+			st.Unchanged++
+			return nil
+		}
+		changed = append(changed, info)
+		paths = append(paths, name)
+		return nil
+	})
+	if err != nil {
+		return st, fmt.Errorf("scan catalog: %w", err)
+	}
+	for name := range c.entries {
+		if !seen[name] {
+			delete(c.entries, name)
+			st.Removed++
+		}
+	}
+	results := read(fsys, paths, changed, c.entries)
+	errs := []error{}
+	for i, res := range results {
+		name := paths[i]
+		if res.err != nil {
+			errs = append(errs, res.err)
+			continue
+		}
+		old, ok := c.entries[name]
+		switch {
+		case !ok:
+			st.Added++
+		case old.Hash == res.entry.Hash:
+			st.Touched++
+		default:
+			st.Updated++
+		}
+		c.entries[name] = res.entry
+	}
+	return st, errors.Join(errs...)
+}
+
+// result is the entry of a file that was read by a scan.
+type result struct {
+	entry *Entry
+	err   error
+}
+
+// read returns the entries of the named files, which are read concurrently.
+// The record of an existing entry is reused when the hash of the file is unchanged.
+func read(fsys fs.FS, paths []string, infos []fs.FileInfo, existing map[string]*Entry) []result {
+	results := make([]result, len(paths))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(runtime.NumCPU(), max(len(paths), 1)) {
+		wg.Go(func() {
+			for i := range jobs {
+				results[i] = readEntry(fsys, paths[i], infos[i], existing[paths[i]])
+			}
+		})
+	}
+	for i := range paths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// readEntry returns the entry of the named file, reusing the record of old when the hash matches.
+func readEntry(fsys fs.FS, name string, info fs.FileInfo, old *Entry) result {
+	b, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return result{err: fmt.Errorf("read %s: %w", name, err)}
+	}
+	sum := sha256.Sum256(b)
+	e := &Entry{
+		Path:    name,
+		Size:    int64(len(b)),
+		ModTime: info.ModTime(),
+		Hash:    hex.EncodeToString(sum[:]),
+	}
+	if old != nil && old.Hash == e.Hash {
+		e.Record = old.Record
+		return result{entry: e}
+	}
+	if !sauce.Contains(b) {
+		return result{entry: e}
+	}
+	if rec := sauce.Decode(b); rec.Valid() {
+		e.Record = &rec
+	}
+	return result{entry: e}
+}
+
+// compressed reports whether the named catalog file is gzip compressed.
+func compressed(name string) bool {
+	return strings.EqualFold(filepath.Ext(name), ".gz")
+}
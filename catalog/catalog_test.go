@@ -0,0 +1,159 @@
+package catalog_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/catalog"
+	"github.com/bengarrett/sauce/internal/fixture"
+)
+
+func collection(t *testing.T) fstest.MapFS {
+	t.Helper()
+	mod := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return fstest.MapFS{
+		"a.ans":       {Data: fixture.Tag(t, "first", fixture.ANSI("First", "", "", 1996)), ModTime: mod},
+		"pack/b.ans":  {Data: fixture.Tag(t, "second", fixture.ANSI("Second", "", "", 1996)), ModTime: mod},
+		"readme.txt":  {Data: []byte("no sauce"), ModTime: mod},
+		".git/config": {Data: []byte("hidden"), ModTime: mod},
+	}
+}
+
+func TestCatalog_Scan(t *testing.T) {
+	t.Parallel()
+	fsys := collection(t)
+	c := catalog.New()
+	st, err := c.Scan(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (catalog.Stats{Added: 3}); st != want {
+		t.Errorf("Scan() = %v, want %v", st, want)
+	}
+	e, ok := c.Lookup("pack/b.ans")
+	if !ok || e.Record == nil || e.Record.Title != "Second" || len(e.Hash) != 64 {
+		t.Errorf("Lookup() = %+v, %t", e, ok)
+	}
+	if e, _ := c.Lookup("readme.txt"); e.Record != nil {
+		t.Errorf("Lookup() record = %+v, want nil", e.Record)
+	}
+
+	later := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fsys["a.ans"].ModTime = later
+	fsys["pack/b.ans"] = &fstest.MapFile{Data: fixture.Tag(t, "second", fixture.ANSI("Retitled", "", "", 1996)), ModTime: later}
+	fsys["new.ans"] = &fstest.MapFile{Data: fixture.Tag(t, "third", fixture.ANSI("Third", "", "", 1996)), ModTime: later}
+	delete(fsys, "readme.txt")
+	st, err = c.Scan(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (catalog.Stats{Added: 1, Updated: 1, Touched: 1, Removed: 1}); st != want {
+		t.Errorf("Scan() = %v, want %v", st, want)
+	}
+	if e, _ := c.Lookup("pack/b.ans"); e.Record.Title != "Retitled" {
+		t.Errorf("Lookup() title = %q, want %q", e.Record.Title, "Retitled")
+	}
+	if e, _ := c.Lookup("a.ans"); !e.ModTime.Equal(later) {
+		t.Errorf("Lookup() mod time = %v, want %v", e.ModTime, later)
+	}
+	st, _ = c.Scan(fsys)
+	if want := (catalog.Stats{Unchanged: 3}); st != want {
+		t.Errorf("Scan() = %v, want %v", st, want)
+	}
+	got := []string{}
+	for _, e := range c.Query(func(e catalog.Entry) bool { return e.Record != nil && strings.HasPrefix(e.Record.Title, "T") }) {
+		got = append(got, e.Path)
+	}
+	if fmt.Sprint(got) != "[new.ans]" {
+		t.Errorf("Query() = %v, want [new.ans]", got)
+	}
+}
+
+func TestCatalog_Save(t *testing.T) {
+	t.Parallel()
+	c := catalog.New()
+	if _, err := c.Scan(collection(t)); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	for _, name := range []string{"sauce.jsonl", "sauce.jsonl.gz"} {
+		path := filepath.Join(dir, name)
+		if err := c.Save(path); err != nil {
+			t.Fatal(err)
+		}
+		got, err := catalog.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want, have bytes.Buffer
+		if err := c.Write(&want); err != nil {
+			t.Fatal(err)
+		}
+		if err := got.Write(&have); err != nil {
+			t.Fatal(err)
+		}
+		// the first line is the header with the time it was saved
+		_, w, _ := strings.Cut(want.String(), "\n")
+		_, h, _ := strings.Cut(have.String(), "\n")
+		if h != w || got.Len() != 3 {
+			t.Errorf("Open(%s) = %s, want %s", name, h, w)
+		}
+	}
+	c, err := catalog.Open(filepath.Join(dir, "missing.jsonl"))
+	if err != nil || c.Len() != 0 {
+		t.Errorf("Open() missing = %d entries, %v", c.Len(), err)
+	}
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		in   string
+		want error
+	}{
+		{"empty", "", catalog.ErrHeader},
+		{"version", `{"version":99}`, catalog.ErrVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := catalog.Read(strings.NewReader(tt.in)); !errors.Is(err, tt.want) {
+				t.Errorf("Read() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleCatalog_Scan() {
+	var logo bytes.Buffer
+	w := sauce.NewWriter(&logo, sauce.Record{Title: "Logo"})
+	_, _ = io.WriteString(w, "hello world")
+	_ = w.Close()
+	fsys := fstest.MapFS{
+		"logo.ans":   {Data: logo.Bytes()},
+		"readme.txt": {Data: []byte("hello world")},
+	}
+	c := catalog.New()
+	st, err := c.Scan(fsys)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(st)
+	for _, e := range c.Entries() {
+		fmt.Println(e.Path, e.Record != nil)
+	}
+	// Output:
+	// 2 files: 2 added, 0 updated, 0 touched, 0 unchanged, 0 removed
+	// logo.ans true
+	// readme.txt false
+}
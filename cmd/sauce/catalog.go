@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/bengarrett/sauce/catalog"
+)
+
+// catalogName is the default name of the catalog file, which is hidden so it is not scanned.
+const catalogName = ".sauce.jsonl.gz"
+
+// catalogPath returns the path of the catalog file of the directory, unless db is set.
+func catalogPath(dir, db string) string {
+	if db != "" {
+		return db
+	}
+	return filepath.Join(dir, catalogName)
+}
+
+// scan updates and saves the catalog of the directory of the arguments,
+// only reading the files that have changed since the last scan.
+func scan(args []string, stdout, stderr io.Writer) error {
+	fs := flags("catalog", stderr)
+	db := fs.String("db", "", "catalog file, which is gzip compressed when it ends with .gz (default DIR/"+catalogName+")")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: a directory is required", ErrUsage)
+	}
+	dir := fs.Arg(0)
+	st, err := os.Stat(dir)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if !st.IsDir() {
+		return fmt.Errorf("%w: %s is not a directory", ErrUsage, dir)
+	}
+	name := catalogPath(dir, *db)
+	c, err := catalog.Open(name)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	stats, scanErr := c.Scan(os.DirFS(dir))
+	if err := c.Save(name); err != nil {
+		return err //nolint:wrapcheck
+	}
+	fmt.Fprintf(stdout, "%s: %s\n", name, stats)
+	return scanErr //nolint:wrapcheck
+}
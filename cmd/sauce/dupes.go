@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bengarrett/sauce/dupes"
+)
+
+// duplicates prints the groups of files of the directory that share the same artwork,
+// and the SAUCE fields that differ between the files of each group.
+func duplicates(args []string, stdout, stderr io.Writer) error {
+	fs := flags("dupes", stderr)
+	normalize := fs.Bool("normalize", false, "ignore any trailing end-of-file markers and whitespace")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: a directory is required", ErrUsage)
+	}
+	dir := fs.Arg(0)
+	st, err := os.Stat(dir)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if !st.IsDir() {
+		return fmt.Errorf("%w: %s is not a directory", ErrUsage, dir)
+	}
+	groups, err := dupes.Find(os.DirFS(dir), *normalize)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	const short = 12
+	for i, g := range groups {
+		if i > 0 {
+			fmt.Fprintln(stdout)
+		}
+		fmt.Fprintf(stdout, "%s  %d files\n", g.String()[:short], len(g.Files))
+		for _, f := range g.Files {
+			path := filepath.Join(dir, filepath.FromSlash(f.Path))
+			about := "(no record)"
+			if r := f.Record; r != nil {
+				about = credits(r.Title, r.Author, r.Group)
+			}
+			fmt.Fprintf(stdout, "  %s  %s\n", path, about)
+		}
+		for _, d := range g.Diff() {
+			values := make([]string, len(d.Values))
+			for i, v := range d.Values {
+				values[i] = strconv.Quote(v)
+			}
+			fmt.Fprintf(stdout, "  %s: %s\n", d.Field, strings.Join(values, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/bengarrett/sauce"
+)
+
+// find prints the paths of the files of the directory whose SAUCE records match the query.
+func find(args []string, stdout, stderr io.Writer) error {
+	fs := flags("find", stderr)
+	db := fs.String("db", "", "catalog file to query instead of the files (default DIR/"+catalogName+" when it exists)")
+	where := fs.String("where", "", "query expression, such as 'group = ACiD and year between 1994 and 1997'")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: a directory is required", ErrUsage)
+	}
+	q, err := sauce.Compile(*where)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+	dir := fs.Arg(0)
+	entries, err := records(dir, *db)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if q.Match(&e.Record) {
+			fmt.Fprintln(stdout, filepath.Join(dir, filepath.FromSlash(e.Path)))
+		}
+	}
+	return nil
+}
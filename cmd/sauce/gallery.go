@@ -0,0 +1,351 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"html/template"
+	"image/png"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/humanize"
+	"github.com/bengarrett/sauce/internal/layout"
+	"github.com/bengarrett/sauce/render"
+	"github.com/bengarrett/sauce/screen"
+	"golang.org/x/text/language"
+)
+
+// ErrPreview is returned when the text of a file is too large to render as a preview.
+var ErrPreview = errors.New("too large to preview")
+
+// previewCells is the maximum number of character cells of a text preview,
+// which is 1,000 lines of 80 columns.
+const previewCells = screen.Columns * 1000
+
+// entry is a file of the gallery.
+type entry struct {
+	Path   string       // path of the file in the directory
+	Size   int64        // size of the content without the SAUCE metadata
+	Record sauce.Record // record of the file, which is empty when the file is not tagged
+}
+
+// Year returns the year of the SAUCE date, or an empty string when it is unknown.
+func (e entry) Year() string {
+	if !e.Record.Valid() || e.Record.Date.Time.IsZero() {
+		return ""
+	}
+	return strconv.Itoa(e.Record.Date.Time.Year())
+}
+
+// Type returns the name of the SAUCE file type, or an empty string when the file is not tagged.
+func (e entry) Type() string {
+	if !e.Record.Valid() {
+		return ""
+	}
+	if e.Record.File.Name != "" {
+		return e.Record.File.Name
+	}
+	return e.Record.Data.Name
+}
+
+// field returns the value of the named column.
+func (e entry) field(name string) string {
+	switch name {
+	case "title":
+		return e.Record.Title
+	case "author":
+		return e.Record.Author
+	case "group":
+		return e.Record.Group
+	case "year":
+		return e.Year()
+	case "type":
+		return e.Type()
+	default:
+		return e.Path
+	}
+}
+
+// columns returns the names of the sortable columns of the gallery.
+func columns() []string {
+	return []string{"name", "title", "author", "group", "year", "type", "size"}
+}
+
+// filters returns the names of the columns that can be filtered by a query value.
+func filters() []string {
+	return []string{"title", "author", "group", "year", "type"}
+}
+
+// gallery is a web gallery of the tagged files of a file system.
+type gallery struct {
+	fsys fs.FS
+	tmpl *template.Template
+	mux  *http.ServeMux
+}
+
+// newGallery returns a web gallery of fsys, which is scanned on each request to its index.
+func newGallery(fsys fs.FS) *gallery {
+	tmpl := template.Must(template.New("head").Parse(head))
+	template.Must(tmpl.New("index").Parse(indexPage))
+	template.Must(tmpl.New("file").Parse(filePage))
+	g := &gallery{fsys: fsys, tmpl: tmpl, mux: http.NewServeMux()}
+	g.mux.HandleFunc("GET /{$}", g.index)
+	g.mux.HandleFunc("GET /file/{path...}", g.file)
+	g.mux.HandleFunc("GET /preview/{path...}", g.preview)
+	g.mux.Handle("GET /raw/", http.StripPrefix("/raw/", http.FileServerFS(sauce.FS(fsys))))
+	return g
+}
+
+func (g *gallery) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+// scan returns every file of the gallery, skipping the hidden files and directories.
+func (g *gallery) scan() ([]entry, error) {
+	return walkDir(g.fsys)
+}
+
+// column is the heading of a sortable column.
+type column struct {
+	Name   string
+	URL    string
+	Active bool
+	Desc   bool
+}
+
+// index writes the list of files, which are filtered by the q query value that matches
+// any column, or the title, author, group, year and type query values that match their column.
+// The sort query value is the column that orders the list, and an order value of desc reverses it.
+func (g *gallery) index(w http.ResponseWriter, r *http.Request) {
+	all, err := g.scan()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	q := r.URL.Query()
+	entries := slices.DeleteFunc(all, func(e entry) bool { return !keep(e, q) })
+	by, desc := q.Get("sort"), q.Get("order") == "desc"
+	if !slices.Contains(columns(), by) {
+		by = "name"
+	}
+	sortEntries(entries, by, desc)
+	heads := make([]column, len(columns()))
+	for i, name := range columns() {
+		v := url.Values{}
+		for key, vals := range q {
+			v[key] = vals
+		}
+		v.Set("sort", name)
+		v.Del("order")
+		if name == by && !desc {
+			v.Set("order", "desc")
+		}
+		heads[i] = column{Name: name, URL: "?" + v.Encode(), Active: name == by, Desc: desc}
+	}
+	values := map[string]string{"q": q.Get("q")}
+	for _, name := range filters() {
+		values[name] = q.Get(name)
+	}
+	g.execute(w, "index", map[string]any{
+		"Title":   "SAUCE gallery",
+		"Entries": entries,
+		"Total":   len(all),
+		"Columns": heads,
+		"Filters": filters(),
+		"Values":  values,
+		"Sort":    by,
+		"Order":   q.Get("order"),
+	})
+}
+
+// keep reports whether the entry matches the filters of the query values.
+func keep(e entry, q url.Values) bool {
+	contains := func(s, substr string) bool {
+		return strings.Contains(strings.ToLower(s), strings.ToLower(strings.TrimSpace(substr)))
+	}
+	for _, name := range filters() {
+		if v := q.Get(name); v != "" && !contains(e.field(name), v) {
+			return false
+		}
+	}
+	v := q.Get("q")
+	if v == "" {
+		return true
+	}
+	for _, name := range columns() {
+		if contains(e.field(name), v) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortEntries sorts the entries by the named column, and then by their path.
+func sortEntries(entries []entry, by string, desc bool) {
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		var n int
+		switch by {
+		case "size":
+			n = cmp.Compare(a.Size, b.Size)
+		case "name":
+		default:
+			n = cmp.Compare(strings.ToLower(a.field(by)), strings.ToLower(b.field(by)))
+		}
+		if n == 0 {
+			n = strings.Compare(a.Path, b.Path)
+		}
+		if desc {
+			return -n
+		}
+		return n
+	})
+}
+
+// item is a named value of the details of a file.
+type item struct {
+	Name  string
+	Value string
+}
+
+// file writes the details of a file, with its comments, flags and a rendered preview.
+func (g *gallery) file(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("path")
+	b, err := fs.ReadFile(g.fsys, name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	rec := sauce.Decode(b)
+	e := entry{Path: name, Size: int64(len(sauce.Trim(b)))}
+	if rec.Valid() {
+		e.Record = rec
+	}
+	items := []item{}
+	add := func(name, value string) {
+		if strings.TrimSpace(value) != "" {
+			items = append(items, item{Name: name, Value: value})
+		}
+	}
+	add("Title", rec.Title)
+	add("Author", rec.Author)
+	add("Group", rec.Group)
+	if e.Year() != "" {
+		add("Date", humanize.DMY.Format(rec.Date.Time))
+	}
+	add("Size", humanize.Decimal(e.Size, language.English))
+	add("Data type", rec.Data.Name)
+	add("File type", rec.File.Name)
+	for _, info := range []layout.Info{rec.Info.Info1, rec.Info.Info2, rec.Info.Info3} {
+		if info.Info != "" && info.Value != 0 {
+			add(info.Info, strconv.Itoa(int(info.Value)))
+		}
+	}
+	add("Font", rec.Info.Font)
+	if e.Record.Valid() && rec.Info.Flags.Decimal != 0 {
+		add("Flags", fmt.Sprintf("%s (%s)", rec.Info.Flags.Binary, rec.Info.Flags.String()))
+	}
+	lines := make([]string, len(e.Record.Comnt.Comment))
+	for i, line := range e.Record.Comnt.Comment {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	_, hasPreview := preview(b)
+	title := rec.Title
+	if !e.Record.Valid() || strings.TrimSpace(title) == "" {
+		title = path.Base(name)
+	}
+	g.execute(w, "file", map[string]any{
+		"Title":    title,
+		"Entry":    e,
+		"Items":    items,
+		"Comments": strings.Join(lines, "\n"),
+		"Preview":  hasPreview,
+	})
+}
+
+// preview writes the rendered image of a file.
+func (g *gallery) preview(w http.ResponseWriter, r *http.Request) {
+	b, err := fs.ReadFile(g.fsys, r.PathValue("path"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	draw, ok := preview(b)
+	if !ok {
+		http.Error(w, "no preview is available", http.StatusNotFound)
+		return
+	}
+	var buf bytes.Buffer
+	ct, err := draw(&buf)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	w.Header().Set("Content-Type", ct)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// preview returns a function to draw the preview image of the file b,
+// which returns the content type of the image, and reports whether a preview is available.
+// Text and binary text is rendered to PNG, while images that a web browser can display
+// are returned without their SAUCE metadata. Files without a record use the type found by [sauce.Sniff].
+func preview(b []byte) (func(*bytes.Buffer) (string, error), bool) {
+	rec := sauce.Decode(b)
+	content := sauce.Trim(b)
+	if !rec.Valid() {
+		rec.Data.Type, rec.File.Type, _ = sauce.Sniff(content)
+	}
+	text := func(buf *bytes.Buffer) (string, error) {
+		s, opts, err := render.Load(b, &rec)
+		if err != nil {
+			return "", err //nolint:wrapcheck
+		}
+		if s.Width()*s.Height() > previewCells {
+			return "", fmt.Errorf("%w: %d columns by %d lines", ErrPreview, s.Width(), s.Height())
+		}
+		if err := png.Encode(buf, render.Image(s, opts)); err != nil {
+			return "", fmt.Errorf("png encode: %w", err)
+		}
+		return "image/png", nil
+	}
+	switch rec.Data.Type {
+	case layout.Characters:
+		switch layout.Character(rec.File.Type) {
+		case layout.ASCII, layout.Ansi, layout.AnsiMation, layout.Source,
+			layout.PCBoard, layout.Avatar, layout.TundraDraw:
+			return text, true
+		case layout.RipScript, layout.HTML:
+		}
+	case layout.BinaryTexts, layout.XBins:
+		return text, true
+	case layout.Bitmaps:
+		switch layout.Bitmap(rec.File.Type) {
+		case layout.Gif, layout.Png, layout.Jpg:
+			return func(buf *bytes.Buffer) (string, error) {
+				buf.Write(content)
+				return http.DetectContentType(content), nil
+			}, true
+		default:
+		}
+	case layout.Nones, layout.Vectors, layout.Audios, layout.Archives, layout.Executables:
+	}
+	return nil, false
+}
+
+// execute writes the named template of the gallery.
+func (g *gallery) execute(w http.ResponseWriter, name string, data any) {
+	var buf bytes.Buffer
+	if err := g.tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(buf.Bytes())
+}
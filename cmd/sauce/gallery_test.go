@@ -0,0 +1,130 @@
+package main
+
+import (
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/fixture"
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+// pack returns a directory of tagged and untagged files.
+func pack(t *testing.T) fstest.MapFS {
+	t.Helper()
+	line := strings.Repeat("\x1b[1;31m\xdb\xdb\x1b[0m ", 10) + "\r\n"
+	logo := fixture.ANSI("Logo", "Zed", "Acid", 1996)
+	logo.Info.Flags = layout.Flags(0b10001).Parse()
+	logo.Comnt.Comment = []string{"greets to everyone"}
+	return fstest.MapFS{
+		"logo.ans":        {Data: fixture.Tag(t, line, logo)},
+		"art/bbs.ans":     {Data: fixture.Tag(t, line, fixture.ANSI("BBS ad", "Amy", "iCE", 1994))},
+		"readme.txt":      {Data: []byte("hello world\r\n")},
+		"sound.bin":       {Data: []byte{0, 1, 2, 3}},
+		".hidden/old.ans": {Data: []byte(line)},
+	}
+}
+
+func get(t *testing.T, h http.Handler, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	return w
+}
+
+func TestGallery_index(t *testing.T) {
+	t.Parallel()
+	g := newGallery(pack(t))
+	w := get(t, g, "/")
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET / = %d", w.Code)
+	}
+	body := w.Body.String()
+	for _, want := range []string{"4 of 4 files", "/file/art/bbs.ans", "BBS ad", "Zed", "Acid", "1996", "ANSI color text"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("GET / does not contain %q", want)
+		}
+	}
+	if strings.Contains(body, "old.ans") {
+		t.Error("GET / contains a hidden file")
+	}
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"?sort=year", []string{"readme.txt", "sound.bin", "art/bbs.ans", "logo.ans"}},
+		{"?sort=year&order=desc", []string{"logo.ans", "art/bbs.ans", "sound.bin", "readme.txt"}},
+		{"?sort=author", []string{"readme.txt", "sound.bin", "art/bbs.ans", "logo.ans"}},
+		{"?q=ice", []string{"art/bbs.ans"}},
+		{"?group=acid", []string{"logo.ans"}},
+		{"?year=199&sort=title", []string{"art/bbs.ans", "logo.ans"}},
+		{"?type=ansi&author=nobody", []string{}},
+	}
+	for _, tt := range tests {
+		body := get(t, g, "/"+tt.query).Body.String()
+		got := []string{}
+		for _, part := range strings.Split(body, `<a href="/file/`)[1:] {
+			got = append(got, part[:strings.Index(part, `"`)])
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("GET /%s = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestGallery_file(t *testing.T) {
+	t.Parallel()
+	g := newGallery(pack(t))
+	body := get(t, g, "/file/logo.ans").Body.String()
+	for _, want := range []string{"<h1>Logo</h1>", "greets to everyone", "10001", "non-blink mode", `src="/preview/logo.ans"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("GET /file/logo.ans does not contain %q", want)
+		}
+	}
+	body = get(t, g, "/file/sound.bin").Body.String()
+	if !strings.Contains(body, "No preview is available") {
+		t.Error("GET /file/sound.bin has a preview")
+	}
+	if w := get(t, g, "/file/missing.ans"); w.Code != http.StatusNotFound {
+		t.Errorf("GET /file/missing.ans = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestGallery_preview(t *testing.T) {
+	t.Parallel()
+	g := newGallery(pack(t))
+	for _, name := range []string{"logo.ans", "readme.txt"} {
+		w := get(t, g, "/preview/"+name)
+		if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "image/png" {
+			t.Fatalf("GET /preview/%s = %d %s", name, w.Code, w.Header().Get("Content-Type"))
+		}
+		if _, err := png.Decode(w.Body); err != nil {
+			t.Errorf("GET /preview/%s is not a png: %v", name, err)
+		}
+	}
+	if w := get(t, g, "/preview/sound.bin"); w.Code != http.StatusNotFound {
+		t.Errorf("GET /preview/sound.bin = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	w := get(t, g, "/raw/logo.ans")
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), sauce.ID) {
+		t.Errorf("GET /raw/logo.ans = %d %q", w.Code, w.Body)
+	}
+}
+
+func TestGallery_previewSize(t *testing.T) {
+	t.Parallel()
+	g := newGallery(fstest.MapFS{
+		"tall.ans": {Data: []byte("\x1b[65535BX")},
+		"long.txt": {Data: []byte(strings.Repeat("hello world\r\n", 1001))},
+	})
+	for _, name := range []string{"tall.ans", "long.txt"} {
+		w := get(t, g, "/preview/"+name)
+		if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), ErrPreview.Error()) {
+			t.Errorf("GET /preview/%s = %d %q, want %d", name, w.Code, w.Body, http.StatusUnprocessableEntity)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Sauce is a command-line tool to browse and query the SAUCE metadata of a collection of files.
+//
+// Usage:
+//
+//	sauce <command> [arguments]
+//
+// The commands are:
+//
+//	catalog  scan a directory into a catalog that only rereads the changed files
+//	dupes    print the files that share the same artwork and how their SAUCE records differ
+//	find     print the files whose SAUCE records match a query expression
+//	search   print the files that match a full-text search, ordered by relevance
+//	serve    start a local web gallery of a directory of tagged files
+//	stats    print the statistics of the SAUCE records of a directory as text, JSON or CSV
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// ErrUsage is returned when the command or its arguments are invalid.
+var ErrUsage = errors.New("invalid usage")
+
+// command is a subcommand of the tool.
+type command struct {
+	usage string // usage is the synopsis of the arguments
+	short string // short description of the command
+	run   func(args []string, stdout, stderr io.Writer) error
+}
+
+// commands returns the subcommands by name.
+func commands() map[string]command {
+	return map[string]command{
+		"catalog": {
+			usage: "catalog [-db file] DIR",
+			short: "scan a directory into a catalog that only rereads the changed files",
+			run:   scan,
+		},
+		"dupes": {
+			usage: "dupes [-normalize] DIR",
+			short: "print the files that share the same artwork and how their SAUCE records differ",
+			run:   duplicates,
+		},
+		"find": {
+			usage: "find [-db file] [-where expr] DIR",
+			short: "print the files whose SAUCE records match a query expression",
+			run:   find,
+		},
+		"search": {
+			usage: "search [-n max] DIR QUERY...",
+			short: "print the files that match a full-text search, ordered by relevance",
+			run:   fullText,
+		},
+		"serve": {
+			usage: "serve [-addr host:port] DIR",
+			short: "start a local web gallery of a directory of tagged files",
+			run:   serve,
+		},
+		"stats": {
+			usage: "stats [-db file] [-where expr] [-format fmt] DIR",
+			short: "print the statistics of the SAUCE records of a directory as text, JSON or CSV",
+			run:   report,
+		},
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command of the arguments and returns the exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	const ok, failed, usage = 0, 1, 2
+	cmds := commands()
+	if len(args) == 0 {
+		help(stderr, cmds)
+		return usage
+	}
+	name := args[0]
+	if name == "help" || name == "-h" || name == "-help" || name == "--help" {
+		help(stdout, cmds)
+		return ok
+	}
+	cmd, found := cmds[name]
+	if !found {
+		fmt.Fprintf(stderr, "sauce: unknown command %q\n\n", name)
+		help(stderr, cmds)
+		return usage
+	}
+	err := cmd.run(args[1:], stdout, stderr)
+	switch {
+	case err == nil:
+		return ok
+	case errors.Is(err, flag.ErrHelp):
+		return ok
+	case errors.Is(err, ErrUsage):
+		fmt.Fprintf(stderr, "sauce %s: %s\nusage: sauce %s\n", name, err, cmd.usage)
+		return usage
+	default:
+		fmt.Fprintf(stderr, "sauce %s: %s\n", name, err)
+		return failed
+	}
+}
+
+// help writes the usage of the tool and its commands.
+func help(w io.Writer, cmds map[string]command) {
+	names := make([]string, 0, len(cmds))
+	for name := range cmds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(w, "Sauce browses and queries the SAUCE metadata of a collection of files.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w)
+	for _, name := range names {
+		fmt.Fprintf(w, "  sauce %-49s %s\n", cmds[name].usage, cmds[name].short)
+	}
+}
+
+// flags returns a flag set of the command that writes its errors and usage to w.
+func flags(name string, w io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("sauce "+name, flag.ContinueOnError)
+	fs.SetOutput(w)
+	return fs
+}
+
+// parse parses the flags of the arguments, where an invalid flag is a usage error.
+func parse(fs *flag.FlagSet, args []string) error {
+	err := fs.Parse(args)
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return err //nolint:wrapcheck
+	}
+	return fmt.Errorf("%w: %w", ErrUsage, err)
+}
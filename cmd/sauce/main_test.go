@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/fixture"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		output string
+	}{
+		{"no command", nil, 2, "Usage:"},
+		{"help", []string{"help"}, 0, "sauce serve"},
+		{"unknown", []string{"unknown"}, 2, `unknown command "unknown"`},
+		{"serve without a dir", []string{"serve"}, 2, "a directory is required"},
+		{"serve a file", []string{"serve", "main.go"}, 2, "is not a directory"},
+		{"serve a missing dir", []string{"serve", "missing"}, 1, "no such file"},
+		{"serve bad flag", []string{"serve", "-bad", "."}, 2, "flag provided but not defined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, &stdout, &stderr)
+			if code != tt.code {
+				t.Errorf("run(%q) = %d, want %d", tt.args, code, tt.code)
+			}
+			if out := stdout.String() + stderr.String(); !strings.Contains(out, tt.output) {
+				t.Errorf("run(%q) output = %q, want %q", tt.args, out, tt.output)
+			}
+		})
+	}
+}
+
+func TestRun_catalog(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "readme.txt"), []byte("hello"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"1 added", "1 unchanged"} {
+		var stdout, stderr bytes.Buffer
+		if code := run([]string{"catalog", dir}, &stdout, &stderr); code != 0 {
+			t.Fatalf("run() = %d: %s", code, &stderr)
+		}
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("run() = %q, want %q", &stdout, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, catalogName)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRun_find(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	for name, data := range pack(t) {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data.Data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	find := func(args ...string) string {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		if code := run(append([]string{"find"}, args...), &stdout, &stderr); code != 0 {
+			t.Fatalf("run(%q) = %d: %s", args, code, &stderr)
+		}
+		return stdout.String()
+	}
+	want := filepath.Join(dir, "art", "bbs.ans") + "\n"
+	if got := find("-where", "group = ice and year < 1995", dir); got != want {
+		t.Errorf("find = %q, want %q", got, want)
+	}
+	if got := find(dir); strings.Count(got, "\n") != 4 {
+		t.Errorf("find = %q, want 4 files", got)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"catalog", dir}, &stdout, &stderr); code != 0 {
+		t.Fatal(&stderr)
+	}
+	// the catalog is queried, so the removed file is still found
+	if err := os.Remove(filepath.Join(dir, "art", "bbs.ans")); err != nil {
+		t.Fatal(err)
+	}
+	if got := find("--where", "filetype = ansi and author = amy", dir); got != want {
+		t.Errorf("find catalog = %q, want %q", got, want)
+	}
+	if code := run([]string{"find", "-where", "year ==", dir}, &stdout, &stderr); code != 2 {
+		t.Errorf("find invalid query = %d, want 2", code)
+	}
+}
+
+func TestRun_search(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	for name, data := range pack(t) {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data.Data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	search := func(args ...string) string {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		if code := run(append([]string{"search"}, args...), &stdout, &stderr); code != 0 {
+			t.Fatalf("run(%q) = %d: %s", args, code, &stderr)
+		}
+		return stdout.String()
+	}
+	if got := search(dir, "greets"); !strings.Contains(got, "logo.ans  Logo by Zed / Acid") {
+		t.Errorf("search = %q, want logo.ans", got)
+	}
+	if got := search(dir, `"hello world"`); !strings.Contains(got, "readme.txt") || strings.Count(got, "\n") != 1 {
+		t.Errorf("search = %q, want readme.txt", got)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"search", dir}, &stdout, &stderr); code != 2 {
+		t.Errorf("search without a query = %d, want 2", code)
+	}
+}
+
+func TestRun_stats(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	for name, data := range pack(t) {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data.Data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{dir}, []string{"tagged         2", "untagged       2", "Acid   1"}},
+		{[]string{"-format", "csv", "-where", "year < 1995", dir}, []string{"total,tagged,1,", "group,iCE,1,", "year,1994,1,"}},
+		{[]string{"-format", "json", dir}, []string{`"untagged": 2`, `"name": "letterspacing"`}},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		if code := run(append([]string{"stats"}, tt.args...), &stdout, &stderr); code != 0 {
+			t.Fatalf("run(%q) = %d: %s", tt.args, code, &stderr)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(stdout.String(), want) {
+				t.Errorf("run(%q) = %q, want %q", tt.args, &stdout, want)
+			}
+		}
+	}
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"stats", "-format", "xml", dir}, &stdout, &stderr); code != 2 {
+		t.Errorf("stats -format xml = %d, want 2", code)
+	}
+}
+
+func TestRun_dupes(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	logo := pack(t)["logo.ans"].Data
+	files := map[string][]byte{
+		"logo.ans":      logo,
+		"copy/logo.ans": fixture.Tag(t, string(sauce.Trim(logo)), fixture.ANSI("Logo", "Amy", "Acid", 1997)),
+		"copy/raw.ans":  append(sauce.Trim(logo), sauce.EOF),
+		"readme.txt":    []byte("hello"),
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dupes := func(args ...string) string {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		if code := run(append([]string{"dupes"}, args...), &stdout, &stderr); code != 0 {
+			t.Fatalf("run(%q) = %d: %s", args, code, &stderr)
+		}
+		return stdout.String()
+	}
+	got := dupes(dir)
+	for _, want := range []string{"  2 files\n", "logo.ans  Logo by Amy / Acid", `author: "Amy", "Zed"`, `date: "1997-01-01", "1996-01-01"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("dupes = %q, want %q", got, want)
+		}
+	}
+	got = dupes("-normalize", dir)
+	if !strings.Contains(got, "  3 files\n") || !strings.Contains(got, "raw.ans  (no record)") {
+		t.Errorf("dupes -normalize = %q, want 3 files", got)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/catalog"
+	"github.com/bengarrett/sauce/internal/layout"
+	"github.com/bengarrett/sauce/internal/walk"
+)
+
+// walkDir returns every file of fsys with its SAUCE record, skipping the hidden files and directories.
+// Only the end of each file is read to find its record.
+func walkDir(fsys fs.FS) ([]entry, error) {
+	entries := []entry{}
+	err := walk.Files(sauce.FS(fsys), func(name string, d fs.DirEntry) error {
+		info, err := d.Info()
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		e := entry{Path: name, Size: info.Size()}
+		if rec, ok := info.Sys().(*sauce.Record); ok && rec.Valid() {
+			e.Record = *rec
+		}
+		entries = append(entries, e)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan directory: %w", err)
+	}
+	return entries, nil
+}
+
+// records returns the files of the directory with their SAUCE records.
+// The catalog file db, or the default catalog of the directory, is used when it exists,
+// so the files are not opened, otherwise the directory is scanned.
+// The size of a catalog entry is the size of the whole file, less the length of its SAUCE metadata.
+func records(dir, db string) ([]entry, error) {
+	st, err := os.Stat(dir)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	if !st.IsDir() {
+		return nil, fmt.Errorf("%w: %s is not a directory", ErrUsage, dir)
+	}
+	name := catalogPath(dir, db)
+	if _, err := os.Stat(name); err != nil {
+		if db != "" || !errors.Is(err, fs.ErrNotExist) {
+			return nil, err //nolint:wrapcheck
+		}
+		return walkDir(os.DirFS(dir))
+	}
+	c, err := catalog.Open(name)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	entries := make([]entry, 0, c.Len())
+	for _, ce := range c.Entries() {
+		e := entry{Path: ce.Path, Size: ce.Size}
+		if ce.Record != nil {
+			e.Record = *ce.Record
+			e.Size = contentSize(ce.Size, ce.Record)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// contentSize returns the size of a file without the SAUCE record r, its comment block
+// and the end-of-file marker. As the catalog does not keep the position of the record,
+// the marker is assumed to be present unless the file size of the record says otherwise.
+func contentSize(size int64, r *sauce.Record) int64 {
+	const sauceLen = 128
+	n := size - sauceLen
+	if len(r.Comnt.Comment) > 0 {
+		n -= int64(len(layout.ComntID) + layout.ComntLineSize*r.Comnt.Count)
+	}
+	if int64(r.FileSize.Bytes) != n {
+		n--
+	}
+	return max(n, 0)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/bengarrett/sauce/internal/fixture"
+)
+
+func TestRecords(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	files := pack(t)
+	// a record without the end-of-file marker
+	b := fixture.Tag(t, "no eof", fixture.ANSI("No EOF", "", "", 1995))
+	files["noeof.ans"] = &fstest.MapFile{Data: slices.Delete(b, len(b)-129, len(b)-128)}
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data.Data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := records(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"catalog", dir}, &stdout, &stderr); code != 0 {
+		t.Fatal(&stderr)
+	}
+	got, err := records(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sizes := func(entries []entry) map[string]int64 {
+		m := map[string]int64{}
+		for _, e := range entries {
+			m[e.Path] = e.Size
+		}
+		return m
+	}
+	if g, w := sizes(got), sizes(want); !maps.Equal(g, w) {
+		t.Errorf("records() catalog sizes = %v, want %v", g, w)
+	}
+}
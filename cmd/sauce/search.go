@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bengarrett/sauce/internal/walk"
+	"github.com/bengarrett/sauce/search"
+)
+
+// fullText prints the files of the directory that match a full-text search of
+// their SAUCE records and the text of their artwork, ordered by relevance.
+func fullText(args []string, stdout, stderr io.Writer) error {
+	fs := flags("search", stderr)
+	limit := fs.Int("n", 20, "maximum number of results, or 0 for every result")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 {
+		return fmt.Errorf("%w: a directory and a query are required", ErrUsage)
+	}
+	dir := fs.Arg(0)
+	query := strings.Join(fs.Args()[1:], " ")
+	st, err := os.Stat(dir)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if !st.IsDir() {
+		return fmt.Errorf("%w: %s is not a directory", ErrUsage, dir)
+	}
+	ix, err := index(os.DirFS(dir))
+	if err != nil {
+		return err
+	}
+	for _, res := range ix.Search(query, *limit) {
+		path := filepath.Join(dir, filepath.FromSlash(res.Path))
+		fmt.Fprintf(stdout, "%6.2f  %s", res.Score, path)
+		if t := credits(res.Record.Title, res.Record.Author, res.Record.Group); t != "" {
+			fmt.Fprintf(stdout, "  %s", t)
+		}
+		fmt.Fprintln(stdout)
+	}
+	return nil
+}
+
+// index returns a full-text index of every file of fsys, skipping the hidden files and directories.
+func index(fsys fs.FS) (*search.Index, error) {
+	ix := search.New()
+	if err := walk.Read(fsys, ix.Add); err != nil {
+		return nil, fmt.Errorf("index directory: %w", err)
+	}
+	return ix, nil
+}
+
+// credits returns the title, author and group of a record as "title by author / group".
+func credits(title, author, group string) string {
+	s := title
+	if author != "" {
+		s += " by " + author
+	}
+	if group != "" {
+		s += " / " + group
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// serve starts a local web gallery of the directory of the arguments,
+// which runs until it is interrupted.
+func serve(args []string, stdout, stderr io.Writer) error {
+	fs := flags("serve", stderr)
+	addr := fs.String("addr", "localhost:8080", "address of the web server")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: a directory is required", ErrUsage)
+	}
+	dir := fs.Arg(0)
+	st, err := os.Stat(dir)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	if !st.IsDir() {
+		return fmt.Errorf("%w: %s is not a directory", ErrUsage, dir)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	var lc net.ListenConfig
+	ln, err := lc.Listen(ctx, "tcp", *addr)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+	const timeout = 10 * time.Second
+	srv := &http.Server{
+		Handler:           newGallery(os.DirFS(dir)),
+		ReadHeaderTimeout: timeout,
+	}
+	fmt.Fprintf(stdout, "serving %s at http://%s, press ctrl+c to stop\n", dir, ln.Addr())
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.Serve(ln)
+	}()
+	select {
+	case err := <-errc:
+		return err //nolint:wrapcheck
+	case <-ctx.Done():
+	}
+	shutdown, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdown); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err //nolint:wrapcheck
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/stats"
+)
+
+// report prints the statistics of the SAUCE records of the directory,
+// optionally limited to the records that match a query.
+func report(args []string, stdout, stderr io.Writer) error {
+	fs := flags("stats", stderr)
+	db := fs.String("db", "", "catalog file to query instead of the files (default DIR/"+catalogName+" when it exists)")
+	where := fs.String("where", "", "query expression, such as 'year = 1996'")
+	format := fs.String("format", "text", "output format, text, json or csv")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: a directory is required", ErrUsage)
+	}
+	f, err := stats.ParseFormat(*format)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+	q, err := sauce.Compile(*where)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+	entries, err := records(fs.Arg(0), *db)
+	if err != nil {
+		return err
+	}
+	var t stats.Tally
+	for _, e := range entries {
+		if q.Match(&e.Record) {
+			t.Add(&e.Record)
+		}
+	}
+	return t.Report().Write(stdout, f) //nolint:wrapcheck
+}
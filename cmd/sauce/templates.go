@@ -0,0 +1,91 @@
+package main
+
+// head is the HTML document head and the style sheet of the gallery pages.
+const head = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { background: #111; color: #ccc; font-family: sans-serif; margin: 1em 2em; }
+a { color: #8cf; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.25em 0.75em; border-bottom: 1px solid #333; }
+th a { text-decoration: none; }
+td.size { text-align: right; }
+form { margin: 1em 0; display: flex; flex-wrap: wrap; gap: 0.5em; }
+dl.sauce dt { font-weight: bold; }
+dl.sauce dd { margin: 0 0 0.5em 0; }
+pre.comments { background: #000; padding: 0.5em; }
+img.preview { max-width: 100%; image-rendering: pixelated; }
+main.file { display: flex; flex-wrap: wrap; gap: 2em; align-items: flex-start; }
+</style>
+</head>
+`
+
+// indexPage is the list of files of the gallery.
+const indexPage = `{{template "head" .}}<body>
+<h1>{{.Title}}</h1>
+<form method="get">
+<input type="search" name="q" value="{{.Values.q}}" placeholder="search">
+{{- range .Filters}}
+<input type="text" name="{{.}}" value="{{index $.Values .}}" placeholder="{{.}}">
+{{- end}}
+<input type="hidden" name="sort" value="{{.Sort}}">
+<input type="hidden" name="order" value="{{.Order}}">
+<button type="submit">filter</button>
+<a href="/">reset</a>
+</form>
+<p>{{len .Entries}} of {{.Total}} files</p>
+<table>
+<thead><tr>
+{{- range .Columns}}
+<th><a href="{{.URL}}">{{.Name}}{{if .Active}}{{if .Desc}} &darr;{{else}} &uarr;{{end}}{{end}}</a></th>
+{{- end}}
+</tr></thead>
+<tbody>
+{{- range .Entries}}
+<tr>
+<td><a href="/file/{{.Path}}">{{.Path}}</a></td>
+<td>{{.Record.Title}}</td>
+<td>{{.Record.Author}}</td>
+<td>{{.Record.Group}}</td>
+<td>{{.Year}}</td>
+<td>{{.Type}}</td>
+<td class="size">{{.Size}}</td>
+</tr>
+{{- end}}
+</tbody>
+</table>
+</body>
+</html>
+`
+
+// filePage is the details of a file of the gallery.
+const filePage = `{{template "head" .}}<body>
+<p><a href="/">gallery</a> / {{.Entry.Path}}</p>
+<h1>{{.Title}}</h1>
+<main class="file">
+<section>
+<dl class="sauce">
+{{- range .Items}}
+<dt>{{.Name}}</dt>
+<dd>{{.Value}}</dd>
+{{- end}}
+</dl>
+{{- if .Comments}}
+<h2>Comments</h2>
+<pre class="comments">{{.Comments}}</pre>
+{{- end}}
+<p><a href="/raw/{{.Entry.Path}}">download without SAUCE</a></p>
+</section>
+{{- if .Preview}}
+<img class="preview" src="/preview/{{.Entry.Path}}" alt="preview of {{.Entry.Path}}">
+{{- else}}
+<p>No preview is available.</p>
+{{- end}}
+</main>
+</body>
+</html>
+`
@@ -0,0 +1,162 @@
+// Package dupes finds the duplicate artwork of a collection of files.
+//
+// Files are grouped by their [sauce.Fingerprint], which is a checksum of the content
+// without the SAUCE metadata, so identical artwork is found when it has different
+// or missing SAUCE records. The records of a group can then be compared to see how they differ.
+package dupes
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/walk"
+)
+
+// File is a file of a group of duplicates.
+type File struct {
+	Path   string        // path of the file
+	Record *sauce.Record // record of the file, or nil when it is not tagged
+}
+
+// Group is the files that share the same content.
+type Group struct {
+	Fingerprint []byte // fingerprint of the content
+	Files       []File // files ordered by path
+}
+
+// String returns the hexadecimal fingerprint of the group.
+func (g Group) String() string {
+	return hex.EncodeToString(g.Fingerprint)
+}
+
+// Difference is a SAUCE field whose values differ between the files of a group.
+type Difference struct {
+	Field  string   // field name, which matches a field of a [sauce.Compile] query
+	Values []string // values of the field in the order of the files, which are empty for files without a record
+}
+
+// field is a comparable SAUCE field.
+type field struct {
+	name  string
+	value func(r *sauce.Record) string
+}
+
+// fields returns the SAUCE fields that are compared.
+func fields() []field {
+	const date = "2006-01-02"
+	return []field{
+		{"title", func(r *sauce.Record) string { return r.Title }},
+		{"author", func(r *sauce.Record) string { return r.Author }},
+		{"group", func(r *sauce.Record) string { return r.Group }},
+		{"date", func(r *sauce.Record) string {
+			if r.Date.Time.IsZero() {
+				return r.Date.Value
+			}
+			return r.Date.Time.Format(date)
+		}},
+		{"size", func(r *sauce.Record) string { return strconv.FormatUint(uint64(r.FileSize.Bytes), 10) }},
+		{"datatype", func(r *sauce.Record) string { return r.Data.Name }},
+		{"filetype", func(r *sauce.Record) string { return r.File.Name }},
+		{"tinfo1", func(r *sauce.Record) string { return strconv.Itoa(int(r.Info.Info1.Value)) }},
+		{"tinfo2", func(r *sauce.Record) string { return strconv.Itoa(int(r.Info.Info2.Value)) }},
+		{"tinfo3", func(r *sauce.Record) string { return strconv.Itoa(int(r.Info.Info3.Value)) }},
+		{"flags", func(r *sauce.Record) string { return strconv.Itoa(int(r.Info.Flags.Decimal)) }},
+		{"font", func(r *sauce.Record) string { return r.Info.Font }},
+		{"comments", func(r *sauce.Record) string { return strings.Join(r.Comnt.Comment, "\n") }},
+	}
+}
+
+// Diff returns the SAUCE fields whose values are not the same for every tagged file of the group.
+// The files without a record are not compared, and have an empty value for every field.
+func (g Group) Diff() []Difference {
+	diffs := []Difference{}
+	for _, f := range fields() {
+		values := make([]string, len(g.Files))
+		tagged := []string{}
+		for i, file := range g.Files {
+			if file.Record != nil {
+				values[i] = strings.TrimSpace(f.value(file.Record))
+				tagged = append(tagged, values[i])
+			}
+		}
+		if slices.ContainsFunc(tagged, func(v string) bool { return v != tagged[0] }) {
+			diffs = append(diffs, Difference{Field: f.name, Values: values})
+		}
+	}
+	return diffs
+}
+
+// Finder groups files by their content. The zero value is ready to use.
+// A Finder is not safe for concurrent use.
+type Finder struct {
+	// Normalize ignores any trailing end-of-file markers and whitespace of the content,
+	// using [sauce.Normalize].
+	Normalize bool
+	groups    map[string]*Group
+}
+
+// Add adds the file b that has the path. Files without any content are ignored.
+func (f *Finder) Add(path string, b []byte) {
+	content := sauce.Trim(b)
+	if f.Normalize {
+		content = sauce.Normalize(b)
+	}
+	if len(content) == 0 {
+		return
+	}
+	if f.groups == nil {
+		f.groups = map[string]*Group{}
+	}
+	sum := sauce.Fingerprint(b)
+	if f.Normalize {
+		sum = sauce.Fingerprint(content)
+	}
+	key := string(sum)
+	g := f.groups[key]
+	if g == nil {
+		g = &Group{Fingerprint: sum}
+		f.groups[key] = g
+	}
+	file := File{Path: path}
+	if sauce.Contains(b) {
+		if r := sauce.Decode(b); r.Valid() {
+			file.Record = &r
+		}
+	}
+	g.Files = append(g.Files, file)
+}
+
+// Groups returns the groups of two or more files that share the same content,
+// ordered by the path of their first file.
+func (f *Finder) Groups() []Group {
+	groups := []Group{}
+	for _, g := range f.groups {
+		if len(g.Files) < 2 {
+			continue
+		}
+		files := slices.Clone(g.Files)
+		slices.SortFunc(files, func(a, b File) int {
+			return strings.Compare(a.Path, b.Path)
+		})
+		groups = append(groups, Group{Fingerprint: g.Fingerprint, Files: files})
+	}
+	slices.SortFunc(groups, func(a, b Group) int {
+		return strings.Compare(a.Files[0].Path, b.Files[0].Path)
+	})
+	return groups
+}
+
+// Find returns the groups of duplicate files of fsys, skipping the hidden files and directories.
+// When normalize is true, any trailing end-of-file markers and whitespace of the content are ignored.
+func Find(fsys fs.FS, normalize bool) ([]Group, error) {
+	f := Finder{Normalize: normalize}
+	if err := walk.Read(fsys, f.Add); err != nil {
+		return nil, fmt.Errorf("find duplicates: %w", err)
+	}
+	return f.Groups(), nil
+}
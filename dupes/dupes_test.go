@@ -0,0 +1,111 @@
+package dupes_test
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/dupes"
+	"github.com/bengarrett/sauce/internal/fixture"
+)
+
+func files(t *testing.T) fstest.MapFS {
+	t.Helper()
+	const art = "\x1b[1;31mlogo\x1b[0m\r\n"
+	return fstest.MapFS{
+		"logo.ans":         {Data: fixture.Tag(t, art, fixture.ANSI("Logo", "Zed", "", 1996))},
+		"mirror/logo.ans":  {Data: fixture.Tag(t, art, fixture.ANSI("Logo", "Amy", "", 1997))},
+		"mirror/copy.ans":  {Data: []byte(art)},
+		"mirror/eof.ans":   {Data: []byte(art + "\x1a")},
+		"other.ans":        {Data: fixture.Tag(t, "other", fixture.ANSI("Other", "Zed", "", 1996))},
+		"empty.txt":        {Data: []byte{}},
+		"blank.txt":        {Data: []byte("\r\n")},
+		".hidden/logo.ans": {Data: []byte(art)},
+	}
+}
+
+func paths(g dupes.Group) []string {
+	s := []string{}
+	for _, f := range g.Files {
+		s = append(s, f.Path)
+	}
+	return s
+}
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+	groups, err := dupes.Find(files(t), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 {
+		t.Fatalf("Find() = %d groups, want 1", len(groups))
+	}
+	want := []string{"logo.ans", "mirror/copy.ans", "mirror/logo.ans"}
+	if got := paths(groups[0]); !slices.Equal(got, want) {
+		t.Errorf("Find() files = %q, want %q", got, want)
+	}
+	if groups[0].Files[1].Record != nil {
+		t.Errorf("Find() untagged record = %+v, want nil", groups[0].Files[1].Record)
+	}
+	if got, want := groups[0].String(), fmt.Sprintf("%x", sauce.Fingerprint([]byte("\x1b[1;31mlogo\x1b[0m\r\n"))); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	groups, err = dupes.Find(files(t), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []string{"logo.ans", "mirror/copy.ans", "mirror/eof.ans", "mirror/logo.ans"}
+	if len(groups) != 1 || !slices.Equal(paths(groups[0]), want) {
+		t.Errorf("Find() normalized = %v, want one group of %q", groups, want)
+	}
+}
+
+func TestGroup_Diff(t *testing.T) {
+	t.Parallel()
+	var f dupes.Finder
+	for name, file := range files(t) {
+		if !strings.HasPrefix(name, ".") {
+			f.Add(name, file.Data)
+		}
+	}
+	groups := f.Groups()
+	if len(groups) != 1 {
+		t.Fatalf("Groups() = %d groups, want 1", len(groups))
+	}
+	diffs := groups[0].Diff()
+	got := []string{}
+	for _, d := range diffs {
+		got = append(got, d.Field)
+	}
+	if want := []string{"author", "date"}; !slices.Equal(got, want) {
+		t.Errorf("Diff() fields = %q, want %q", got, want)
+	}
+	if want := []string{"Zed", "", "Amy"}; !slices.Equal(diffs[0].Values, want) {
+		t.Errorf("Diff() author = %q, want %q", diffs[0].Values, want)
+	}
+}
+
+func ExampleFinder() {
+	var f dupes.Finder
+	for _, author := range []string{"Zed", "Amy"} {
+		var buf bytes.Buffer
+		w := sauce.NewWriter(&buf, sauce.Record{Title: "Logo", Author: author})
+		_, _ = w.Write([]byte("hello world"))
+		_ = w.Close()
+		f.Add(author+".ans", buf.Bytes())
+	}
+	for _, g := range f.Groups() {
+		fmt.Println(len(g.Files), "files")
+		for _, d := range g.Diff() {
+			fmt.Println(d.Field, d.Values)
+		}
+	}
+	// Output:
+	// 2 files
+	// author [Amy Zed]
+}
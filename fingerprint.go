@@ -0,0 +1,25 @@
+package sauce
+
+import (
+	"bytes"
+	"crypto/sha256"
+)
+
+// Fingerprint returns the SHA-256 checksum of the content of b returned by [Trim],
+// so the same artwork has the same fingerprint whether it has a different
+// SAUCE record, or no record at all.
+//
+// To also ignore any trailing end-of-file markers and whitespace,
+// which are often added or removed when a file is tagged, use:
+//
+//	sauce.Fingerprint(sauce.Normalize(b))
+func Fingerprint(b []byte) []byte {
+	sum := sha256.Sum256(Trim(b))
+	return sum[:]
+}
+
+// Normalize returns the content of b returned by [Trim], without any trailing
+// SUB end-of-file markers, NUL padding, spaces, tabs or newlines.
+func Normalize(b []byte) []byte {
+	return bytes.TrimRight(Trim(b), "\x00\t\n\r \x1a")
+}
@@ -0,0 +1,70 @@
+package sauce_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/fixture"
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+	const text = "\x1b[1;31mhello world\x1b[0m\r\n"
+	content := []byte(text)
+	logo := sauce.Record{Title: "Logo", Data: layout.Datas{Type: layout.Characters}}
+	other := sauce.Record{Title: "Another logo", Author: "Zed"}
+	want := sauce.Fingerprint(content)
+	if len(want) != 32 {
+		t.Fatalf("Fingerprint() length = %d, want 32", len(want))
+	}
+	tests := []struct {
+		name      string
+		b         []byte
+		same      bool
+		normalize bool
+	}{
+		{"tagged", fixture.Tag(t, text, logo), true, true},
+		{"retagged", fixture.Tag(t, text, other), true, true},
+		{"eof marker", append(bytes.Clone(content), sauce.EOF), false, true},
+		{"trailing whitespace", append(bytes.Clone(content), "  \r\n\x00"...), false, true},
+		{"tagged with whitespace", fixture.Tag(t, text+"\n", logo), false, true},
+		{"different content", []byte("hello world"), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := sauce.Fingerprint(tt.b); bytes.Equal(got, want) != tt.same {
+				t.Errorf("Fingerprint() = %x, want same as %x is %t", got, want, tt.same)
+			}
+			norm := sauce.Fingerprint(sauce.Normalize(content))
+			if got := sauce.Fingerprint(sauce.Normalize(tt.b)); bytes.Equal(got, norm) != tt.normalize {
+				t.Errorf("normalized Fingerprint() = %x, want same as %x is %t", got, norm, tt.normalize)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+	b := fixture.Tag(t, "  hello \x00\r\n\x1a\x1a", sauce.Record{Title: "hello"})
+	if got, want := sauce.Normalize(b), []byte("  hello"); !bytes.Equal(got, want) {
+		t.Errorf("Normalize() = %q, want %q", got, want)
+	}
+}
+
+func ExampleFingerprint() {
+	art := []byte("hello world")
+	var buf bytes.Buffer
+	w := sauce.NewWriter(&buf, sauce.Record{Title: "Hello"})
+	_, _ = w.Write(art)
+	_ = w.Close()
+	fmt.Println(hex.EncodeToString(sauce.Fingerprint(art)))
+	fmt.Println(bytes.Equal(sauce.Fingerprint(art), sauce.Fingerprint(buf.Bytes())))
+	// Output:
+	// b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9
+	// true
+}
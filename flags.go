@@ -0,0 +1,31 @@
+package sauce
+
+import "github.com/bengarrett/sauce/internal/layout"
+
+// Flags is the SAUCE ANSiFlags bitfield, which is found in the Info.Flags.Decimal
+// field of a record and provides the non-blink mode, letter-spacing and aspect ratio.
+// The bits are read in the order of their binary notation, so B is bit 4, LS is bits 3-2
+// and AR is bits 1-0, which is the reverse of the diagram in the SAUCE specification.
+type Flags = layout.Flags
+
+// LS is the letter-spacing value of the ANSiFlags, the 8 or 9 pixel font selection.
+type LS = layout.LS
+
+// AR is the aspect ratio value of the ANSiFlags.
+type AR = layout.AR
+
+// Letter-spacing values.
+const (
+	LSNone     = layout.LSNone     // legacy value of no preference
+	LS8px      = layout.LS8px      // select an 8 pixel font
+	LS9px      = layout.LS9px      // select a 9 pixel font
+	LSReserved = layout.LSReserved // reserved value that is not valid
+)
+
+// Aspect ratio values.
+const (
+	ARNone     = layout.ARNone     // legacy value of no preference
+	ARStretch  = layout.ARStretch  // stretch the pixels to the display of a legacy device
+	ARSquare   = layout.ARSquare   // square pixels, as displayed on a modern device
+	ARReserved = layout.ARReserved // reserved value that is not valid
+)
@@ -0,0 +1,161 @@
+package sauce
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+)
+
+// FS returns a file system of fsys where the content of the regular files
+// is returned without any SAUCE metadata or the optional end-of-file marker.
+//
+// The Size of the file information is the length of the content, and Sys
+// returns the decoded *[Record], which is empty when a file has no SAUCE metadata.
+// The Index fields of the record are the positions within the whole file.
+// The files support io.Seeker and io.ReaderAt, so the file system can be served
+// by [net/http.FileServerFS]. The file information of directory entries is
+// read from the files, so their sizes also exclude the SAUCE metadata.
+func FS(fsys fs.FS) fs.FS {
+	return sauceFS{fsys: fsys}
+}
+
+type sauceFS struct {
+	fsys fs.FS
+}
+
+func (s sauceFS) Open(name string) (fs.File, error) {
+	f, err := s.fsys.Open(name)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err //nolint:wrapcheck
+	}
+	if d, ok := f.(fs.ReadDirFile); ok && info.IsDir() {
+		return &dir{ReadDirFile: d, fsys: s, name: name}, nil
+	}
+	if !info.Mode().IsRegular() {
+		return f, nil
+	}
+	sf, err := newFile(f, info)
+	if err != nil {
+		f.Close()
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return sf, nil
+}
+
+// file is a regular file without its SAUCE metadata.
+type file struct {
+	*io.SectionReader
+	f    fs.File
+	info fileInfo
+}
+
+// newFile returns the file f without its SAUCE metadata.
+// Only the end of a file that implements io.ReaderAt is read,
+// otherwise the whole file is read into memory.
+func newFile(f fs.File, info fs.FileInfo) (*file, error) {
+	ra, ok := f.(io.ReaderAt)
+	if !ok {
+		b, err := io.ReadAll(f)
+		if err != nil {
+			return nil, fmt.Errorf("read file: %w", err)
+		}
+		ra, info = bytes.NewReader(b), sizeInfo{info, int64(len(b))}
+	}
+	size := info.Size()
+	offset := max(0, size-int64(tailSize))
+	tail := make([]byte, size-offset)
+	n, err := ra.ReadAt(tail, offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+	tail = tail[:n]
+	rec := Decode(tail)
+	rec.offset(int(offset))
+	content := offset + int64(len(Trim(tail)))
+	return &file{
+		SectionReader: io.NewSectionReader(ra, 0, content),
+		f:             f,
+		info:          fileInfo{FileInfo: info, size: content, rec: &rec},
+	}, nil
+}
+
+func (f *file) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *file) Close() error {
+	return f.f.Close() //nolint:wrapcheck
+}
+
+// fileInfo is the file information of a file without its SAUCE metadata.
+type fileInfo struct {
+	fs.FileInfo
+	size int64
+	rec  *Record
+}
+
+// Size returns the length of the content without the SAUCE metadata.
+func (fi fileInfo) Size() int64 {
+	return fi.size
+}
+
+// Sys returns the decoded SAUCE *Record of the file.
+func (fi fileInfo) Sys() any {
+	return fi.rec
+}
+
+// sizeInfo is the file information with the size of the file that was read.
+type sizeInfo struct {
+	fs.FileInfo
+	size int64
+}
+
+func (si sizeInfo) Size() int64 {
+	return si.size
+}
+
+// dir is a directory of a file system without SAUCE metadata.
+type dir struct {
+	fs.ReadDirFile
+	fsys sauceFS
+	name string
+}
+
+// ReadDir returns the directory entries, with the file information of the
+// regular files read without their SAUCE metadata.
+func (d *dir) ReadDir(n int) ([]fs.DirEntry, error) {
+	entries, err := d.ReadDirFile.ReadDir(n)
+	for i, e := range entries {
+		entries[i] = dirEntry{DirEntry: e, fsys: d.fsys, name: path.Join(d.name, e.Name())}
+	}
+	return entries, err //nolint:wrapcheck
+}
+
+// dirEntry is a directory entry of a file system without SAUCE metadata.
+type dirEntry struct {
+	fs.DirEntry
+	fsys sauceFS
+	name string
+}
+
+// Info returns the file information of the entry.
+// Regular files are opened to find the length of their content.
+func (e dirEntry) Info() (fs.FileInfo, error) {
+	if !e.Type().IsRegular() {
+		return e.DirEntry.Info() //nolint:wrapcheck
+	}
+	f, err := e.fsys.Open(e.name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.Stat() //nolint:wrapcheck
+}
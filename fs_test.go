@@ -0,0 +1,125 @@
+package sauce_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/bengarrett/sauce"
+)
+
+// readOnly is a file system of files that do not implement io.ReaderAt.
+type readOnly struct{ fs.FS }
+
+func (r readOnly) Open(name string) (fs.File, error) {
+	f, err := r.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return struct{ fs.File }{f}, nil
+}
+
+func TestFS(t *testing.T) {
+	t.Parallel()
+	raw, err := static.ReadFile(example)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapfs := fstest.MapFS{
+		"art/sauce.txt": {Data: raw},
+		"art/plain.txt": {Data: []byte("hello world")},
+	}
+	for name, fsys := range map[string]fs.FS{"embed": static, "map": mapfs, "read only": readOnly{mapfs}} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			path := example
+			if name != "embed" {
+				path = "art/sauce.txt"
+			}
+			sfs := sauce.FS(fsys)
+			got, err := fs.ReadFile(sfs, path)
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+			want := sauce.Trim(raw)
+			if !bytes.Equal(got, want) {
+				t.Errorf("ReadFile() = %d bytes, want %d", len(got), len(want))
+			}
+			info, err := fs.Stat(sfs, path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Size() != int64(len(want)) {
+				t.Errorf("Stat() size = %d, want %d", info.Size(), len(want))
+			}
+			rec, ok := info.Sys().(*sauce.Record)
+			if !ok || !rec.Valid() || rec.Title != "Sauce title" {
+				t.Errorf("Stat() sys = %v", info.Sys())
+			}
+		})
+	}
+	if err := fstest.TestFS(sauce.FS(mapfs), "art/sauce.txt", "art/plain.txt"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFS_Index(t *testing.T) {
+	t.Parallel()
+	raw, err := static.ReadFile(example)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// large content that is longer than the tail that is read
+	large := append(bytes.Repeat([]byte("0123456789abcdef"), 4096), raw...)
+	mapfs := fstest.MapFS{"large.txt": {Data: large}}
+	want := sauce.Decode(large)
+	for name, fsys := range map[string]fs.FS{"map": mapfs, "read only": readOnly{mapfs}} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			info, err := fs.Stat(sauce.FS(fsys), "large.txt")
+			if err != nil {
+				t.Fatal(err)
+			}
+			rec, ok := info.Sys().(*sauce.Record)
+			if !ok || rec.Index != want.Index || rec.Comnt.Index != want.Comnt.Index {
+				t.Errorf("Stat() sys index = %v, want %d, %d", info.Sys(), want.Index, want.Comnt.Index)
+			}
+		})
+	}
+}
+
+func TestFS_http(t *testing.T) {
+	t.Parallel()
+	raw, err := static.ReadFile(example)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(http.FileServerFS(sauce.FS(static)))
+	defer srv.Close()
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+"/"+example, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Range", "bytes=0-4")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusPartialContent || !bytes.Equal(b, raw[:5]) {
+		t.Errorf("GET range = %d %q, want %q", res.StatusCode, b, raw[:5])
+	}
+	want := fmt.Sprintf("bytes 0-4/%d", len(sauce.Trim(raw)))
+	if got := res.Header.Get("Content-Range"); got != want {
+		t.Errorf("Content-Range = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,150 @@
+package sauce
+
+import (
+	"math"
+	"strings"
+
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+// Geometry is the on-screen size of the artwork described by a SAUCE record.
+//
+// Text is drawn in character cells using the font of the TInfoS field,
+// and the ANSiFlags letter-spacing selects the 8 or 9 pixel wide cells of a VGA font.
+// When the ANSiFlags aspect ratio asks for the pixels to be stretched, the native
+// pixel size is scaled to the 4:3 display of the legacy CRT monitor of the font's
+// screen mode, so an 80x25 VGA screen of 720x400 pixels is displayed at 720x540.
+type Geometry struct {
+	Columns       int     `json:"columns"       xml:"columns"`        // columns is the character width, or 0 for pixel based images
+	Rows          int     `json:"rows"          xml:"rows"`           // rows are the number of lines, or 0 for pixel based images
+	CellWidth     int     `json:"cellWidth"     xml:"cell_width"`     // cellwidth is the pixel width of a character cell
+	CellHeight    int     `json:"cellHeight"    xml:"cell_height"`    // cellheight is the pixel height of a character cell
+	Width         int     `json:"width"         xml:"width"`          // width is the native pixel width
+	Height        int     `json:"height"        xml:"height"`         // height is the native pixel height
+	DisplayWidth  int     `json:"displayWidth"  xml:"display_width"`  // displaywidth is the pixel width when displayed
+	DisplayHeight int     `json:"displayHeight" xml:"display_height"` // displayheight is the pixel height when displayed
+	ScaleX        float64 `json:"scaleX"        xml:"scale_x"`        // scalex is the horizontal scale of the native pixels
+	ScaleY        float64 `json:"scaleY"        xml:"scale_y"`        // scaley is the vertical scale of the native pixels
+	Aspect        float64 `json:"aspect"        xml:"aspect"`         // aspect is the display aspect ratio, the display width divided by the height
+}
+
+// metrics are the character cell size of a font and the pixel size of its screen mode.
+type metrics struct {
+	width, height int // character cell in pixels
+	modeW, modeH  int // screen mode in pixels
+	ibm           bool
+}
+
+// Geometry returns the on-screen size of the artwork described by the record.
+//
+// Character and binary text use the TInfo1 character width, the TInfo2 number
+// of lines, the TInfoS font and the ANSiFlags. An unset width is 80 columns.
+// The bitmap and RIPscrip types use the TInfo1 and TInfo2 pixel sizes.
+// A zero Geometry is returned for the other data and file types.
+func (r *Record) Geometry() Geometry {
+	cols, rows := int(r.Info.Info1.Value), int(r.Info.Info2.Value)
+	switch r.Data.Type {
+	case layout.Characters:
+		switch layout.Character(r.File.Type) {
+		case layout.ASCII, layout.Ansi, layout.AnsiMation, layout.PCBoard, layout.Avatar, layout.TundraDraw:
+			return r.text(cols, rows)
+		case layout.RipScript:
+			// ripscrip uses the 640x350 EGA screen mode displayed at 4:3
+			const egaW, egaH = 640, 350
+			return pixels(cols, rows, float64(egaW*3)/float64(egaH*4))
+		case layout.HTML, layout.Source:
+		}
+	case layout.BinaryTexts:
+		const width, pair = 160, 2
+		cols = int(r.File.Type) * pair
+		if cols == 0 {
+			cols = width
+		}
+		size := int(r.FileSize.Bytes)
+		return r.text(cols, (size+cols*pair-1)/(cols*pair))
+	case layout.XBins:
+		return r.text(cols, rows)
+	case layout.Bitmaps:
+		return pixels(cols, rows, 1)
+	case layout.Nones, layout.Vectors, layout.Audios, layout.Archives, layout.Executables:
+	}
+	return Geometry{}
+}
+
+// text returns the geometry of the character cells of the columns and rows.
+func (r *Record) text(cols, rows int) Geometry {
+	const columns, nine = 80, 9
+	if cols == 0 {
+		cols = columns
+	}
+	m := fontMetrics(r.Info.Font)
+	if m.ibm && r.Info.Flags.LetterSpacing() == LS9px {
+		m.width, m.modeW = nine, m.modeW/m.width*nine
+	}
+	g := Geometry{
+		Columns:    cols,
+		Rows:       rows,
+		CellWidth:  m.width,
+		CellHeight: m.height,
+		Width:      cols * m.width,
+		Height:     rows * m.height,
+		ScaleX:     1,
+		ScaleY:     1,
+	}
+	if r.Info.Flags.AspectRatio() == ARStretch {
+		// the screen mode is stretched to fill a 4:3 display
+		g.ScaleY = float64(m.modeW*3) / float64(m.modeH*4)
+	}
+	return g.display()
+}
+
+// pixels returns the geometry of a pixel based image that is vertically scaled.
+func pixels(width, height int, scaleY float64) Geometry {
+	g := Geometry{Width: width, Height: height, ScaleX: 1, ScaleY: scaleY}
+	return g.display()
+}
+
+// display sets the display size and aspect ratio using the scale factors.
+func (g Geometry) display() Geometry {
+	g.DisplayWidth = int(math.Round(float64(g.Width) * g.ScaleX))
+	g.DisplayHeight = int(math.Round(float64(g.Height) * g.ScaleY))
+	if g.DisplayHeight > 0 {
+		g.Aspect = float64(g.DisplayWidth) / float64(g.DisplayHeight)
+	}
+	return g
+}
+
+// fontMetrics returns the metrics of the SAUCE font name, such as "IBM VGA" or "Amiga Topaz 1+".
+// The IBM VGA metrics are returned for an empty or unknown name,
+// and any code page suffix of the IBM fonts is ignored.
+//
+// See http://www.acid.org/info/sauce/sauce.htm#FontName
+func fontMetrics(name string) metrics {
+	vga := metrics{width: 8, height: 16, modeW: 640, modeH: 400, ibm: true}
+	fields := strings.Fields(name)
+	if len(fields) == 0 {
+		return vga
+	}
+	switch fields[0] {
+	case "Amiga":
+		return metrics{width: 8, height: 8, modeW: 640, modeH: 200}
+	case "C64":
+		return metrics{width: 8, height: 8, modeW: 320, modeH: 200}
+	case "Atari":
+		return metrics{width: 8, height: 8, modeW: 320, modeH: 192}
+	}
+	if fields[0] != "IBM" || len(fields) < 2 {
+		return vga
+	}
+	switch fields[1] {
+	case "VGA50":
+		vga.height = 8
+	case "VGA25G":
+		vga.height, vga.modeH = 19, 480
+	case "EGA":
+		return metrics{width: 8, height: 14, modeW: 640, modeH: 350, ibm: true}
+	case "EGA43":
+		return metrics{width: 8, height: 8, modeW: 640, modeH: 350, ibm: true}
+	}
+	return vga
+}
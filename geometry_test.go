@@ -0,0 +1,66 @@
+package sauce_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+func TestRecord_Geometry(t *testing.T) {
+	t.Parallel()
+	flags := func(ls sauce.LS, ar sauce.AR) sauce.Flags {
+		var f sauce.Flags
+		f.SetLetterSpacing(ls)
+		f.SetAspectRatio(ar)
+		return f
+	}
+	var (
+		none    = flags(sauce.LSNone, sauce.ARNone)
+		stretch = flags(sauce.LSNone, sauce.ARStretch)
+		eightPx = flags(sauce.LS8px, sauce.ARNone)
+		ninePx  = flags(sauce.LS9px, sauce.ARNone)
+	)
+	tests := []struct {
+		name          string
+		data          layout.TypeOfData
+		file          layout.TypeOfFile
+		cols, rows    uint16
+		flags         sauce.Flags
+		font          string
+		width, height int
+		dispW, dispH  int
+		aspect        float64
+	}{
+		{"vga 9px stretch", layout.Characters, 1, 80, 25, ninePx | stretch, "IBM VGA", 720, 400, 720, 540, 4.0 / 3},
+		{"vga 8px stretch", layout.Characters, 1, 80, 25, eightPx | stretch, "IBM VGA", 640, 400, 640, 480, 4.0 / 3},
+		{"vga no stretch", layout.Characters, 1, 80, 25, ninePx, "", 720, 400, 720, 400, 1.8},
+		{"vga50", layout.Characters, 1, 80, 50, stretch, "IBM VGA50 437", 640, 400, 640, 480, 4.0 / 3},
+		{"ega", layout.Characters, 0, 80, 25, stretch, "IBM EGA", 640, 350, 640, 480, 4.0 / 3},
+		{"amiga", layout.Characters, 0, 80, 25, ninePx | stretch, "Amiga Topaz 1+", 640, 200, 640, 480, 4.0 / 3},
+		{"no width", layout.Characters, 1, 0, 10, none, "", 640, 160, 640, 160, 4},
+		{"rip", layout.Characters, 3, 640, 350, none, "", 640, 350, 640, 480, 4.0 / 3},
+		{"bitmap", layout.Bitmaps, 0, 320, 200, none, "", 320, 200, 320, 200, 1.6},
+		{"xbin", layout.XBins, 0, 160, 2, none, "", 1280, 32, 1280, 32, 40},
+		{"audio", layout.Audios, 0, 8000, 0, none, "", 0, 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := sauce.Record{}
+			r.Data.Type, r.File.Type = tt.data, tt.file
+			r.Info.Info1.Value, r.Info.Info2.Value = tt.cols, tt.rows
+			r.Info.Flags = tt.flags.Parse()
+			r.Info.Font = tt.font
+			g := r.Geometry()
+			if g.Width != tt.width || g.Height != tt.height || g.DisplayWidth != tt.dispW || g.DisplayHeight != tt.dispH {
+				t.Errorf("Geometry() = %dx%d displayed at %dx%d, want %dx%d displayed at %dx%d",
+					g.Width, g.Height, g.DisplayWidth, g.DisplayHeight, tt.width, tt.height, tt.dispW, tt.dispH)
+			}
+			if math.Abs(g.Aspect-tt.aspect) > 0.001 {
+				t.Errorf("Geometry() aspect = %f, want %f", g.Aspect, tt.aspect)
+			}
+		})
+	}
+}
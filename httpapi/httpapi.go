@@ -0,0 +1,247 @@
+// Package httpapi provides a net/http handler to inspect, strip, re-tag and lint
+// the SAUCE metadata of uploaded files.
+//
+// Files are uploaded with a POST request, either as the raw request body or as
+// the "file" field of a multipart form. The endpoints of the handler are:
+//
+//	POST /record  returns the SAUCE record as JSON or XML
+//	POST /strip   returns the file without its SAUCE metadata
+//	POST /retag   returns the file with a new SAUCE record
+//	POST /lint    returns the problems with the SAUCE metadata as JSON or XML
+//
+// The record and lint responses are XML when the Accept header prefers
+// "application/xml" or "text/xml", otherwise they are JSON.
+// Only the end of an upload is held in memory by the record, strip and lint
+// endpoints, as the SAUCE metadata is always found in the last 16 KB of a file.
+//
+// The handler can be mounted at a path prefix using [net/http.StripPrefix].
+package httpapi
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bengarrett/sauce"
+)
+
+// DefaultMaxBytes is the default size limit of an upload, 32 MB.
+const DefaultMaxBytes = 32 << 20
+
+var (
+	ErrNoFile = errors.New("upload has no file")
+	ErrDate   = errors.New("date is not in the yyyymmdd format")
+)
+
+// Options are the settings of the handler.
+type Options struct {
+	// MaxBytes is the size limit of an upload, or DefaultMaxBytes when it is 0.
+	MaxBytes int64
+	// Tag are the options used by [sauce.AutoTag] when a file without
+	// a SAUCE record is re-tagged.
+	Tag sauce.TagOptions
+}
+
+// Report is the result of the lint endpoint.
+type Report struct {
+	XMLName  xml.Name `json:"-"        xml:"report"`
+	Name     string   `json:"name"     xml:"name,attr"` // name of the uploaded file, when known
+	Size     int64    `json:"size"     xml:"size,attr"` // size of the content without the SAUCE metadata
+	Valid    bool     `json:"valid"    xml:"valid,attr"`
+	Problems []string `json:"problems" xml:"problem"` // problems found with the SAUCE metadata
+}
+
+type handler struct {
+	opts Options
+	mux  *http.ServeMux
+}
+
+// New returns a handler of the inspect, strip, re-tag and lint endpoints.
+func New(opts Options) http.Handler {
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = DefaultMaxBytes
+	}
+	h := &handler{opts: opts, mux: http.NewServeMux()}
+	h.mux.HandleFunc("POST /record", h.record)
+	h.mux.HandleFunc("POST /strip", h.strip)
+	h.mux.HandleFunc("POST /retag", h.retag)
+	h.mux.HandleFunc("POST /lint", h.lint)
+	return h
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.ContentLength > h.opts.MaxBytes {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, h.opts.MaxBytes)
+	h.mux.ServeHTTP(w, r)
+}
+
+// record writes the SAUCE record of the upload.
+func (h *handler) record(w http.ResponseWriter, r *http.Request) {
+	u, err := open(r)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	rec, _, err := u.tail()
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	if !rec.Valid() {
+		fail(w, sauce.ErrNoRecord)
+		return
+	}
+	write(w, r, &rec)
+}
+
+// strip writes the upload without its SAUCE metadata.
+func (h *handler) strip(w http.ResponseWriter, r *http.Request) {
+	u, err := open(r)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	u.headers(w)
+	// the response is streamed, so an error after the first write cannot change the status
+	_, _ = io.Copy(w, sauce.NewTrimReader(u))
+}
+
+// retag writes the upload with a new SAUCE record.
+//
+// The record of the upload is kept, or a new record is created using [sauce.AutoTag].
+// The title, author, group and date query values replace the fields of the record,
+// and any comment query values replace the comment lines.
+func (h *handler) retag(w http.ResponseWriter, r *http.Request) {
+	u, err := open(r)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	b, err := io.ReadAll(u)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	rec := sauce.Decode(b)
+	b = sauce.Trim(b)
+	if !rec.Valid() {
+		opts := h.opts.Tag
+		if opts.ModTime.IsZero() {
+			opts.ModTime = time.Now()
+		}
+		rec = sauce.AutoTag(u.name, b, opts)
+	}
+	if err := fields(&rec, r); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	meta, err := rec.MarshalBinary()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	u.headers(w)
+	w.Header().Set("Content-Length", strconv.Itoa(len(b)+1+len(meta)))
+	sw := sauce.NewWriter(w, rec)
+	if _, err := sw.Write(b); err != nil {
+		return
+	}
+	_ = sw.Close()
+}
+
+// lint writes a report of the problems with the SAUCE metadata of the upload.
+func (h *handler) lint(w http.ResponseWriter, r *http.Request) {
+	u, err := open(r)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	rec, size, err := u.tail()
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	report := Report{Name: u.name, Size: size, Valid: true, Problems: []string{}}
+	if err := rec.Lint(size); err != nil {
+		report.Valid = false
+		report.Problems = problems(err)
+	}
+	write(w, r, &report)
+}
+
+// fields sets the record fields of the query values of the request.
+func fields(rec *sauce.Record, r *http.Request) error {
+	q := r.URL.Query()
+	if q.Has("title") {
+		rec.Title = q.Get("title")
+	}
+	if q.Has("author") {
+		rec.Author = q.Get("author")
+	}
+	if q.Has("group") {
+		rec.Group = q.Get("group")
+	}
+	if q.Has("date") {
+		t, err := time.Parse(sauce.Date, q.Get("date"))
+		if err != nil {
+			return fmt.Errorf("%w: %q", ErrDate, q.Get("date"))
+		}
+		rec.Date.Time = t
+	}
+	if q.Has("comment") {
+		rec.Comnt.Comment = q["comment"]
+	}
+	return nil
+}
+
+// problems returns the messages of the errors joined by [errors.Join].
+func problems(err error) []string {
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) {
+		return []string{err.Error()}
+	}
+	s := []string{}
+	for _, e := range joined.Unwrap() {
+		s = append(s, e.Error())
+	}
+	return s
+}
+
+// write encodes v as JSON or XML, depending on the Accept header of the request.
+func write(w http.ResponseWriter, r *http.Request, v any) {
+	if acceptXML(r.Header.Get("Accept")) {
+		w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+		_, _ = io.WriteString(w, xml.Header)
+		enc := xml.NewEncoder(w)
+		enc.Indent("", "  ")
+		_ = enc.Encode(v)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+// fail writes the error with the status code of its cause.
+func fail(w http.ResponseWriter, err error) {
+	var maxErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxErr):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, ErrNoFile):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, sauce.ErrNoRecord):
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
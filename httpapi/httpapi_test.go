@@ -0,0 +1,208 @@
+package httpapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/httpapi"
+	"github.com/bengarrett/sauce/internal/fixture"
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+// content is longer than a comment line, as a comment block is only found after the first line.
+const content = "\x1b[1;33mhello world\x1b[0m\r\n\x1b[36mthis is a line of ansi text that is long enough\x1b[0m\r\n"
+
+// file returns the content tagged with a SAUCE record.
+func file(t *testing.T) []byte {
+	t.Helper()
+	rec := fixture.ANSI("hello", "ben", "", 1996)
+	rec.Date = layout.Dates{Time: time.Date(1996, 5, 4, 0, 0, 0, 0, time.UTC)}
+	rec.Comnt = layout.Comment{Comment: []string{"a comment"}}
+	return fixture.Tag(t, content, rec)
+}
+
+// multipartBody returns a multipart form with a text field and the file b.
+func multipartBody(t *testing.T, name string, b []byte) (*bytes.Buffer, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	if err := mw.WriteField("note", "ignored"); err != nil {
+		t.Fatal(err)
+	}
+	fw, err := mw.CreateFormFile("file", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fw.Write(b); err != nil {
+		t.Fatal(err)
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf, mw.FormDataContentType()
+}
+
+func serve(h http.Handler, target, contentType, accept string, body io.Reader) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, body)
+	req.Header.Set("Content-Type", contentType)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestRecord(t *testing.T) {
+	t.Parallel()
+	h := httpapi.New(httpapi.Options{})
+	b := file(t)
+	w := serve(h, "/record", "application/octet-stream", "", bytes.NewReader(b))
+	if w.Code != http.StatusOK {
+		t.Fatalf("POST /record = %d %s", w.Code, w.Body)
+	}
+	var rec sauce.Record
+	if err := json.Unmarshal(w.Body.Bytes(), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Title != "hello" || rec.Author != "ben" || len(rec.Comnt.Comment) != 1 {
+		t.Errorf("POST /record = %+v", rec)
+	}
+	body, ct := multipartBody(t, "hello.ans", b)
+	w = serve(h, "/record", ct, "text/html, application/xml;q=0.9, application/json;q=0.8", body)
+	if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, "application/xml") {
+		t.Errorf("POST /record content type = %q, want xml", got)
+	}
+	rec = sauce.Record{}
+	if err := xml.Unmarshal(w.Body.Bytes(), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Title != "hello" {
+		t.Errorf("POST /record title = %q, want %q", rec.Title, "hello")
+	}
+	w = serve(h, "/record", "text/plain", "", strings.NewReader(content))
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("POST /record without sauce = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestStrip(t *testing.T) {
+	t.Parallel()
+	h := httpapi.New(httpapi.Options{})
+	body, ct := multipartBody(t, "hello.ans", file(t))
+	w := serve(h, "/strip", ct, "", body)
+	if w.Code != http.StatusOK || w.Body.String() != content {
+		t.Errorf("POST /strip = %d %q, want %q", w.Code, w.Body, content)
+	}
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename=hello.ans` {
+		t.Errorf("POST /strip disposition = %q", got)
+	}
+	w = serve(h, "/strip", "multipart/form-data; boundary=x", "", strings.NewReader("--x--\r\n"))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("POST /strip without a file = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestRetag(t *testing.T) {
+	t.Parallel()
+	h := httpapi.New(httpapi.Options{})
+	w := serve(h, "/retag?title=new+title&group=acid&date=19950102&comment=one&comment=two",
+		"application/octet-stream", "", bytes.NewReader(file(t)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("POST /retag = %d %s", w.Code, w.Body)
+	}
+	b := w.Body.Bytes()
+	if err := sauce.Lint(b); err != nil {
+		t.Errorf("POST /retag lint = %v", err)
+	}
+	rec := sauce.Decode(b)
+	if rec.Title != "new title" || rec.Author != "ben" || rec.Group != "acid" ||
+		rec.Date.Value != "19950102" || strings.Join(strings.Fields(strings.Join(rec.Comnt.Comment, " ")), " ") != "one two" {
+		t.Errorf("POST /retag = %+v", rec)
+	}
+	if got := string(sauce.Trim(b)); got != content {
+		t.Errorf("POST /retag content = %q, want %q", got, content)
+	}
+	w = serve(h, "/retag?name=art/greets.ans", "application/octet-stream", "", strings.NewReader(content))
+	if rec := sauce.Decode(w.Body.Bytes()); rec.Title != "greets" || rec.File.Type != layout.TypeOfFile(layout.Ansi) {
+		t.Errorf("POST /retag autotag = %+v", rec)
+	}
+	w = serve(h, "/retag?date=yesterday", "application/octet-stream", "", strings.NewReader(content))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("POST /retag bad date = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+	h := httpapi.New(httpapi.Options{})
+	tests := []struct {
+		name  string
+		b     []byte
+		valid bool
+	}{
+		{"valid", file(t), true},
+		{"padded", append([]byte("xx"), file(t)...), false},
+		{"no record", []byte(content), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			w := serve(h, "/lint?name="+url.QueryEscape(tt.name), "application/octet-stream", "", bytes.NewReader(tt.b))
+			var got httpapi.Report
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Valid != tt.valid || got.Valid != (len(got.Problems) == 0) {
+				t.Errorf("POST /lint = %+v, want valid %t", got, tt.valid)
+			}
+		})
+	}
+}
+
+func TestNew_MaxBytes(t *testing.T) {
+	t.Parallel()
+	h := httpapi.New(httpapi.Options{MaxBytes: 64})
+	big := bytes.Repeat([]byte("x"), 128)
+	w := serve(h, "/record", "application/octet-stream", "", bytes.NewReader(big))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("POST /record = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+	// a chunked upload has an unknown length
+	w = serve(h, "/lint", "application/octet-stream", "", io.MultiReader(bytes.NewReader(big)))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("POST /lint chunked = %d, want %d", w.Code, http.StatusRequestEntityTooLarge)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/record", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /record = %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func ExampleNew() {
+	srv := httptest.NewServer(httpapi.New(httpapi.Options{}))
+	defer srv.Close()
+	res, err := http.Post(srv.URL+"/strip?name=hello.txt", "text/plain",
+		strings.NewReader("hello world\x1aSAUCE00"+strings.Repeat(" ", 121)))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer res.Body.Close()
+	b, _ := io.ReadAll(res.Body)
+	fmt.Printf("%q\n", b)
+	// Output: "hello world"
+}
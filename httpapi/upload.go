@@ -0,0 +1,104 @@
+package httpapi
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/bengarrett/sauce"
+)
+
+// upload is the file of a request.
+type upload struct {
+	io.Reader
+	name string // name of the file, when known
+}
+
+// open returns the uploaded file of the request.
+// A multipart form uses the "file" field, or the first part with a file name,
+// otherwise the request body is the file and the "name" query value is its name.
+func open(r *http.Request) (*upload, error) {
+	mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mt != "multipart/form-data" {
+		return &upload{Reader: r.Body, name: path.Base("/" + r.URL.Query().Get("name"))}, nil
+	}
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("multipart upload: %w", err)
+	}
+	for {
+		p, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrNoFile
+		}
+		if err != nil {
+			return nil, fmt.Errorf("multipart upload: %w", err)
+		}
+		if p.FormName() == "file" || p.FileName() != "" {
+			return &upload{Reader: p, name: p.FileName()}, nil
+		}
+		if err := discard(p); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// discard reads the rest of the multipart form part.
+func discard(p *multipart.Part) error {
+	if _, err := io.Copy(io.Discard, p); err != nil {
+		return fmt.Errorf("multipart upload: %w", err)
+	}
+	return nil
+}
+
+// tail reads the upload to the end and returns its SAUCE record and the
+// size of the content without the metadata. Only the end of the upload is kept in memory.
+func (u *upload) tail() (sauce.Record, int64, error) {
+	tr := sauce.NewTrimReader(u)
+	n, err := io.Copy(io.Discard, tr)
+	if err != nil {
+		return sauce.Record{}, 0, fmt.Errorf("read upload: %w", err)
+	}
+	return tr.Record(), n, nil
+}
+
+// headers sets the headers of a response that returns the file.
+func (u *upload) headers(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if name := u.name; name != "" && name != "/" && name != "." {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	}
+}
+
+// acceptXML reports whether the Accept header prefers XML to JSON.
+func acceptXML(accept string) bool {
+	best, xml := 0.0, false
+	for _, s := range strings.Split(accept, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(s))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q <= best {
+			continue
+		}
+		switch mt {
+		case "application/xml", "text/xml":
+			best, xml = q, true
+		case "application/json", "*/*", "application/*":
+			best, xml = q, false
+		}
+	}
+	return xml
+}
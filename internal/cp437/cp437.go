@@ -0,0 +1,48 @@
+// Package cp437 maps the IBM PC code page 437 character set to Unicode.
+//
+// Unlike the [charmap.CodePage437] decoder, the C0 control codes are mapped
+// to the glyphs that a PC displays in text mode, as used by ANSI and ASCII art.
+package cp437
+
+import (
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// House is the glyph of the DEL character 0x7f.
+const House = '⌂'
+
+// Rune returns the Unicode rune of the CP437 character c.
+func Rune(c byte) rune {
+	const del = 0x7f
+	switch {
+	case c < ' ':
+		return control(c)
+	case c == del:
+		return House
+	case c < del:
+		return rune(c)
+	default:
+		return charmap.CodePage437.DecodeByte(c)
+	}
+}
+
+// String returns the CP437 text b as a UTF-8 string.
+func String(b []byte) string {
+	var sb strings.Builder
+	sb.Grow(len(b))
+	for _, c := range b {
+		sb.WriteRune(Rune(c))
+	}
+	return sb.String()
+}
+
+// control returns the glyph of the C0 control code c as displayed in text mode.
+// The NUL character is displayed as a space.
+func control(c byte) rune {
+	return [...]rune{
+		' ', '☺', '☻', '♥', '♦', '♣', '♠', '•', '◘', '○', '◙', '♂', '♀', '♪', '♫', '☼',
+		'►', '◄', '↕', '‼', '¶', '§', '▬', '↨', '↑', '↓', '→', '←', '∟', '↔', '▲', '▼',
+	}[c&0x1f]
+}
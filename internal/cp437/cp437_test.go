@@ -0,0 +1,41 @@
+package cp437_test
+
+import (
+	"testing"
+
+	"github.com/bengarrett/sauce/internal/cp437"
+)
+
+func TestRune(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		c    byte
+		want rune
+	}{
+		{"nul", 0x00, ' '},
+		{"smiley", 0x01, '☺'},
+		{"arrow", 0x1a, '→'},
+		{"ascii", 'A', 'A'},
+		{"house", 0x7f, '⌂'},
+		{"shade", 0xb0, '░'},
+		{"block", 0xdb, '█'},
+		{"nbsp", 0xff, ' '},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cp437.Rune(tt.c); got != tt.want {
+				t.Errorf("Rune() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	t.Parallel()
+	const want = "☺ Hello █▓▒░"
+	if got := cp437.String([]byte("\x01 Hello \xdb\xb2\xb1\xb0")); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,42 @@
+// Package fixture creates the SAUCE records and tagged files that are shared by the tests.
+package fixture
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+// ANSI returns a SAUCE record of an ANSI text file dated the 1st of January of the year.
+func ANSI(title, author, group string, year int) sauce.Record {
+	date := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return sauce.Record{
+		ID:      sauce.ID,
+		Version: sauce.Version,
+		Title:   title,
+		Author:  author,
+		Group:   group,
+		Date:    layout.Dates{Value: date.Format(sauce.Date), Time: date},
+		Data:    layout.Datas{Type: layout.Characters, Name: layout.Characters.String()},
+		File:    layout.Files{Type: layout.TypeOfFile(layout.Ansi), Name: layout.Ansi.String()},
+	}
+}
+
+// Tag returns the content followed by the SAUCE metadata of the record,
+// as written by a [sauce.NewWriter]. Any error fails the test.
+func Tag(tb testing.TB, content string, rec sauce.Record) []byte {
+	tb.Helper()
+	var buf bytes.Buffer
+	w := sauce.NewWriter(&buf, rec)
+	if _, err := io.WriteString(w, content); err != nil {
+		tb.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		tb.Fatal(err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,28 @@
+package fixture_test
+
+import (
+	"testing"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/fixture"
+)
+
+func TestTag(t *testing.T) {
+	t.Parallel()
+	b := fixture.Tag(t, "hello", fixture.ANSI("Logo", "Zed", "ACiD", 1996))
+	if got := string(sauce.Trim(b)); got != "hello" {
+		t.Errorf("Tag() content = %q, want hello", got)
+	}
+	r := sauce.Decode(b)
+	if !r.Valid() {
+		t.Fatal("Tag() is not a valid record")
+	}
+	want := fixture.ANSI("Logo", "Zed", "ACiD", 1996)
+	if r.Title != want.Title || r.Author != want.Author || r.Group != want.Group {
+		t.Errorf("Tag() = %q, %q, %q, want %q, %q, %q", r.Title, r.Author, r.Group, want.Title, want.Author, want.Group)
+	}
+	if r.Date.Value != want.Date.Value || r.Data.Type != want.Data.Type || r.File.Name != want.File.Name {
+		t.Errorf("Tag() = %s, %s, %s, want %s, %s, %s",
+			r.Date.Value, r.Data.Type, r.File.Name, want.Date.Value, want.Data.Type, want.File.Name)
+	}
+}
@@ -0,0 +1,99 @@
+// Package font provides the IBM PC code page 437 bitmap fonts of a text mode screen.
+//
+// Each font has 256 glyphs that are 8 pixels wide, stored as one byte per row
+// with the most significant bit as the leftmost pixel, in the format of the
+// MS-DOS .F16 and .F08 font files.
+package font
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Glyphs is the number of characters in a font.
+const Glyphs = 256
+
+// Width is the pixel width of a glyph.
+const Width = 8
+
+// Font names used by the SAUCE TInfoS field.
+// See http://www.acid.org/info/sauce/sauce.htm#FontName
+const (
+	VGA   = "IBM VGA"   // VGA 8x16 glyphs for 80x25 text
+	VGA50 = "IBM VGA50" // VGA 8x8 glyphs for 80x50 text
+	EGA43 = "IBM EGA43" // EGA 8x8 glyphs for 80x43 text
+)
+
+const (
+	vgaPx   = 16 // vgapx is the height of the VGA glyphs
+	smallPx = 8  // smallpx is the height of the VGA50 and EGA43 glyphs
+)
+
+// ErrSize is returned when the font data is an invalid length.
+var ErrSize = errors.New("font data is not 256 glyphs")
+
+//go:embed static/vga.f16
+var vga16 []byte
+
+//go:embed static/vga.f08
+var vga8 []byte
+
+// Font is a bitmap font of 256 glyphs.
+type Font struct {
+	Name   string // name of the font
+	Height int    // height of a glyph in pixels
+	data   []byte
+}
+
+// New returns the font of 256 glyphs found in the bitmap data.
+// The glyph height is the length of the data divided by 256.
+func New(name string, data []byte) (*Font, error) {
+	if len(data) == 0 || len(data)%Glyphs != 0 {
+		return nil, fmt.Errorf("%w: %d bytes", ErrSize, len(data))
+	}
+	return &Font{Name: name, Height: len(data) / Glyphs, data: data}, nil
+}
+
+// Default returns the IBM VGA 8x16 font.
+func Default() *Font {
+	return &Font{Name: VGA, Height: vgaPx, data: vga16}
+}
+
+// Small returns the IBM VGA50 8x8 font.
+func Small() *Font {
+	return &Font{Name: VGA50, Height: smallPx, data: vga8}
+}
+
+// Lookup returns the font of the SAUCE font name, such as "IBM VGA" or "IBM VGA50 437".
+// The IBM VGA font is returned for an empty or unknown name.
+// Only code page 437 glyphs are available, so any code page suffix is ignored.
+func Lookup(name string) *Font {
+	fields := strings.Fields(name)
+	const ibm = 2
+	if len(fields) < ibm {
+		return Default()
+	}
+	switch strings.Join(fields[:ibm], " ") {
+	case VGA50:
+		return Small()
+	case EGA43:
+		f := Small()
+		f.Name = EGA43
+		return f
+	}
+	return Default()
+}
+
+// Glyph returns the rows of pixels of the character c.
+func (f *Font) Glyph(c byte) []byte {
+	i := int(c) * f.Height
+	return f.data[i : i+f.Height]
+}
+
+// LineDrawing reports whether c is one of the box drawing characters
+// that a VGA card extends into the ninth column of a 9 pixel wide glyph.
+func LineDrawing(c byte) bool {
+	return c >= 0xc0 && c <= 0xdf
+}
@@ -0,0 +1,83 @@
+package font_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/bengarrett/sauce/internal/font"
+)
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		wantName   string
+		wantHeight int
+	}{
+		{"", font.VGA, 16},
+		{"IBM VGA", font.VGA, 16},
+		{"IBM VGA 437", font.VGA, 16},
+		{"IBM VGA50", font.VGA50, 8},
+		{"IBM VGA50 865", font.VGA50, 8},
+		{"IBM EGA43", font.EGA43, 8},
+		{"Amiga Topaz 1", font.VGA, 16},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f := font.Lookup(tt.name)
+			if f.Name != tt.wantName {
+				t.Errorf("Lookup().Name = %q, want %q", f.Name, tt.wantName)
+			}
+			if f.Height != tt.wantHeight {
+				t.Errorf("Lookup().Height = %d, want %d", f.Height, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestFont_Glyph(t *testing.T) {
+	t.Parallel()
+	f := font.Default()
+	if got := f.Glyph(' '); !bytes.Equal(got, make([]byte, 16)) {
+		t.Errorf("Glyph(' ') = %x, want a blank glyph", got)
+	}
+	full := bytes.Repeat([]byte{0xff}, 16)
+	if got := f.Glyph(0xdb); !bytes.Equal(got, full) {
+		t.Errorf("Glyph(0xdb) = %x, want %x", got, full)
+	}
+	const horizontal = 0xc4
+	if got := f.Glyph(horizontal)[7]; got != 0xff {
+		t.Errorf("Glyph(0xc4)[7] = %x, want ff", got)
+	}
+	if got := font.Small().Glyph('A'); len(got) != 8 {
+		t.Errorf("Small().Glyph('A') length = %d, want 8", len(got))
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	f, err := font.New("custom", make([]byte, 256*14))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if f.Height != 14 {
+		t.Errorf("New().Height = %d, want 14", f.Height)
+	}
+	_, err = font.New("bad", make([]byte, 100))
+	if !errors.Is(err, font.ErrSize) {
+		t.Errorf("New() error = %v, want %v", err, font.ErrSize)
+	}
+}
+
+func TestLineDrawing(t *testing.T) {
+	t.Parallel()
+	if !font.LineDrawing(0xc4) {
+		t.Error("LineDrawing(0xc4) = false, want true")
+	}
+	if font.LineDrawing('A') {
+		t.Error("LineDrawing('A') = true, want false")
+	}
+}
@@ -3,6 +3,8 @@ package layout
 import (
 	"errors"
 	"fmt"
+	"math/bits"
+	"strconv"
 	"strings"
 )
 
@@ -13,6 +15,11 @@ import (
 // LS: Letter-spacing (a.k.a. 8/9 pixel font selection).
 // AR: Aspect Ratio.
 // See http://www.acid.org/info/sauce/sauce.htm#ANSiFlags.
+//
+// Unlike the diagram, this package reads the five low bits in the order of their
+// binary notation, as its earlier string based parsing did. So B is bit 4,
+// LS is bits 3-2 and AR is bits 1-0, and a value of 1 is a stretch aspect ratio,
+// not the non-blink mode.
 
 var ErrInvalid = errors.New("invalid value")
 
@@ -20,9 +27,59 @@ var ErrInvalid = errors.New("invalid value")
 // It acts as an unsupported placeholder for SAUCE versions prior to v00.5 from Nov 2013.
 const Unsupported = "no preference"
 
-// Flags is the SAUCE Flags field.
+// Flags is the SAUCE Flags field, a bitfield of the non-blink mode,
+// letter-spacing and aspect ratio values.
+// The setters only keep the five low bits, so they clear any unused bits.
 type Flags uint8
 
+// The bits of the Flags field.
+const (
+	nonBlink Flags = 1 << 4                     // nonblink is the B bit
+	lsMask   Flags = 0b11 << 2                  // lsmask are the LS bits
+	arMask   Flags = 0b11                       // armask are the AR bits
+	used           = nonBlink | lsMask | arMask // used are the B, LS and AR bits
+	lsShift        = 2                          // lsshift is the position of the LS bits
+	reserved       = 0b11                       // reserved is the "11" value of the LS and AR bits
+)
+
+// LS is the letter-spacing value of the Flags field.
+type LS uint8
+
+const (
+	LSNone     LS = iota // legacy value of no preference
+	LS8px                // select an 8 pixel font
+	LS9px                // select a 9 pixel font
+	LSReserved           // reserved "11" value that is not valid
+)
+
+func (ls LS) String() string {
+	return ls.bits().String()
+}
+
+// bits returns the two bit letter-spacing value.
+func (ls LS) bits() LsBit {
+	return [...]LsBit{"00", "01", "10", "11"}[ls&reserved]
+}
+
+// AR is the aspect ratio value of the Flags field.
+type AR uint8
+
+const (
+	ARNone     AR = iota // legacy value of no preference
+	ARStretch            // stretch the pixels to the display of a legacy device
+	ARSquare             // square pixels, as displayed on a modern device
+	ARReserved           // reserved "11" value that is not valid
+)
+
+func (ar AR) String() string {
+	return ar.bits().String()
+}
+
+// bits returns the two bit aspect ratio value.
+func (ar AR) bits() ArBit {
+	return [...]ArBit{"00", "01", "10", "11"}[ar&reserved]
+}
+
 // ANSIFlags allow an author of ANSi and similar files to provide a clue to a viewer/editor how to render the image.
 type ANSIFlags struct {
 	Decimal         Flags      `json:"decimal"       xml:"decimal,attr"`   // decimal, unsigned integer flags value
@@ -61,26 +118,129 @@ type ANSIFlagB struct {
 	Info string `json:"interpretation" xml:"interpretation,attr"` // info description of the toggle
 }
 
+// Parse returns the ANSIFlags of the bitfield, with the binary notation and
+// the humanized descriptions of the non-blink, letter-spacing and aspect ratio bits.
 func (f Flags) Parse() ANSIFlags {
-	const binary5Bits, minLen = "%05b", 5
-	bin := fmt.Sprintf(binary5Bits, f)
-	r := []rune(bin)
-	if len(r) < minLen {
-		return ANSIFlags{
-			Decimal: f,
-			Binary:  bin,
-		}
-	}
-	b, ls, ar := string(r[0]), string(r[1:3]), string(r[3:5])
+	const width = 5
+	bin := strconv.FormatUint(uint64(f), 2)
+	if n := len(bin); n < width {
+		bin = strings.Repeat("0", width-n) + bin
+	}
+	b := BBit("0")
+	if f.NonBlink() {
+		b = "1"
+	}
+	ls, ar := f.LetterSpacing(), f.AspectRatio()
 	return ANSIFlags{
 		Decimal: f,
 		Binary:  bin,
-		B:       ANSIFlagB{Flag: BBit(b), Info: BBit(b).String()},
-		LS:      ANSIFlagLS{Flag: LsBit(ls), Info: LsBit(ls).String()},
-		AR:      ANSIFlagAR{Flag: ArBit(ar), Info: ArBit(ar).String()},
+		B:       ANSIFlagB{Flag: b, Info: b.String()},
+		LS:      ANSIFlagLS{Flag: ls.bits(), Info: ls.String()},
+		AR:      ANSIFlagAR{Flag: ar.bits(), Info: ar.String()},
 	}
 }
 
+// bits returns the five bits of the B, LS and AR values.
+// A value that uses the unused bits above them is read from its five leading binary digits,
+// which is how the string based parsing of earlier versions interpreted these values.
+// The shifted bits are only used for reading and are never written back by the setters.
+func (f Flags) bits() Flags {
+	const width = 5
+	if n := bits.Len8(uint8(f)); n > width {
+		return f >> (n - width)
+	}
+	return f
+}
+
+// NonBlink reports whether the non-blink mode, also known as iCE colors, is set.
+func (f Flags) NonBlink() bool {
+	return f.bits()&nonBlink != 0
+}
+
+// LetterSpacing returns the letter-spacing, the 8 or 9 pixel font selection.
+func (f Flags) LetterSpacing() LS {
+	return LS(f.bits() & lsMask >> lsShift)
+}
+
+// AspectRatio returns the aspect ratio of the pixels.
+func (f Flags) AspectRatio() AR {
+	return AR(f.bits() & arMask)
+}
+
+// SetNonBlink sets or clears the non-blink mode.
+// As the unused bits are cleared, the other values of a bitfield
+// that used them are then read from the five low bits.
+func (f *Flags) SetNonBlink(v bool) {
+	*f &= used
+	if v {
+		*f |= nonBlink
+		return
+	}
+	*f &^= nonBlink
+}
+
+// SetLetterSpacing sets the letter-spacing and clears the unused bits.
+func (f *Flags) SetLetterSpacing(ls LS) {
+	*f = *f&used&^lsMask | Flags(ls&reserved)<<lsShift
+}
+
+// SetAspectRatio sets the aspect ratio and clears the unused bits.
+func (f *Flags) SetAspectRatio(ar AR) {
+	*f = *f&used&^arMask | Flags(ar&reserved)
+}
+
+// Valid returns an error when the bitfield uses any unused bits,
+// or the reserved "11" letter-spacing and aspect ratio values.
+func (f Flags) Valid() error {
+	var errs []error
+	if f&^used != 0 {
+		errs = append(errs, fmt.Errorf("%w: unused bits %08b", ErrInvalid, f))
+	}
+	if f.LetterSpacing() == LSReserved {
+		errs = append(errs, fmt.Errorf("%w: letter-spacing %s", ErrInvalid, string(LSReserved.bits())))
+	}
+	if f.AspectRatio() == ARReserved {
+		errs = append(errs, fmt.Errorf("%w: aspect ratio %s", ErrInvalid, string(ARReserved.bits())))
+	}
+	return errors.Join(errs...)
+}
+
+// NonBlink reports whether the non-blink mode, also known as iCE colors, is set.
+func (a ANSIFlags) NonBlink() bool {
+	return a.Decimal.NonBlink()
+}
+
+// LetterSpacing returns the letter-spacing, the 8 or 9 pixel font selection.
+func (a ANSIFlags) LetterSpacing() LS {
+	return a.Decimal.LetterSpacing()
+}
+
+// AspectRatio returns the aspect ratio of the pixels.
+func (a ANSIFlags) AspectRatio() AR {
+	return a.Decimal.AspectRatio()
+}
+
+// SetNonBlink sets or clears the non-blink mode and updates the interpretations.
+func (a *ANSIFlags) SetNonBlink(v bool) {
+	f := a.Decimal
+	f.SetNonBlink(v)
+	*a = f.Parse()
+}
+
+// SetLetterSpacing sets the letter-spacing and updates the interpretations.
+func (a *ANSIFlags) SetLetterSpacing(ls LS) {
+	f := a.Decimal
+	f.SetLetterSpacing(ls)
+	*a = f.Parse()
+}
+
+// SetAspectRatio sets the aspect ratio and updates the interpretations.
+func (a *ANSIFlags) SetAspectRatio(ar AR) {
+	f := a.Decimal
+	f.SetAspectRatio(ar)
+	*a = f.Parse()
+}
+
 // ANSIFlagLS is the interpretation of the SAUCE Flags letter spacing binary bits.
 type ANSIFlagLS struct {
 	Flag LsBit  `json:"flag"           xml:"flag"`                // lsbit letter-spacing value
@@ -2,6 +2,7 @@ package layout_test
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/bengarrett/sauce/internal/layout"
@@ -42,6 +43,7 @@ func TestFlags_parse(t *testing.T) {
 		{"four", 4, blink, px8, noPref, "blink mode, select 8 pixel font"},
 		{"five", 5, blink, px8, stretch, "blink mode, select 8 pixel font, stretch pixels"},
 		{"no blink", 99, noBlink, px9, noPref, "non-blink mode, select 9 pixel font"},
+		{"unused bits", 32, noBlink, noPref, noPref, "non-blink mode"},
 		{"max", 255, noBlink, invalid, invalid, "non-blink mode, invalid value, invalid value"},
 	}
 	for _, tt := range tests {
@@ -63,6 +65,104 @@ func TestFlags_parse(t *testing.T) {
 	}
 }
 
+func TestFlags_set(t *testing.T) {
+	t.Parallel()
+	var f layout.Flags
+	f.SetNonBlink(true)
+	f.SetLetterSpacing(layout.LS9px)
+	f.SetAspectRatio(layout.ARStretch)
+	if f != 0b11001 {
+		t.Errorf("Flags = %05b, want %05b", f, 0b11001)
+	}
+	if !f.NonBlink() || f.LetterSpacing() != layout.LS9px || f.AspectRatio() != layout.ARStretch {
+		t.Errorf("Flags = %v, %v, %v", f.NonBlink(), f.LetterSpacing(), f.AspectRatio())
+	}
+	f.SetNonBlink(false)
+	f.SetLetterSpacing(layout.LS8px)
+	if f != 0b00101 {
+		t.Errorf("Flags = %05b, want %05b", f, 0b00101)
+	}
+	// 99 is read from its leading binary digits 11000, but the setters
+	// clear the unused bits of 1100011 rather than write back the shifted value
+	f = 99
+	if !f.NonBlink() || f.LetterSpacing() != layout.LS9px || f.AspectRatio() != layout.ARNone {
+		t.Errorf("Flags(99) = %v, %v, %v", f.NonBlink(), f.LetterSpacing(), f.AspectRatio())
+	}
+	f.SetAspectRatio(layout.ARSquare)
+	if f != 0b00010 {
+		t.Errorf("Flags with unused bits = %05b, want %05b", f, 0b00010)
+	}
+	if f.NonBlink() || f.LetterSpacing() != layout.LSNone || f.AspectRatio() != layout.ARSquare {
+		t.Errorf("Flags = %v, %v, %v", f.NonBlink(), f.LetterSpacing(), f.AspectRatio())
+	}
+	a := layout.Flags(0).Parse()
+	a.SetNonBlink(true)
+	if a.Decimal != 16 || a.B.Flag != "1" || a.Binary != "10000" || a.String() != "non-blink mode" {
+		t.Errorf("ANSIFlags.SetNonBlink() = %+v", a)
+	}
+}
+
+func TestFlags_bits(t *testing.T) {
+	t.Parallel()
+	// the bits are read in the order of the binary notation, which is the reverse of the spec diagram
+	tests := []struct {
+		name string
+		f    layout.Flags
+		b    bool
+		ls   layout.LS
+		ar   layout.AR
+	}{
+		{"bit 0", 0b00001, false, layout.LSNone, layout.ARStretch},
+		{"bit 1", 0b00010, false, layout.LSNone, layout.ARSquare},
+		{"bit 2", 0b00100, false, layout.LS8px, layout.ARNone},
+		{"bit 3", 0b01000, false, layout.LS9px, layout.ARNone},
+		{"bit 4", 0b10000, true, layout.LSNone, layout.ARNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if tt.f.NonBlink() != tt.b || tt.f.LetterSpacing() != tt.ls || tt.f.AspectRatio() != tt.ar {
+				t.Errorf("Flags(%05b) = %v, %v, %v, want %v, %v, %v", tt.f,
+					tt.f.NonBlink(), tt.f.LetterSpacing(), tt.f.AspectRatio(), tt.b, tt.ls, tt.ar)
+			}
+			var f layout.Flags
+			f.SetNonBlink(tt.b)
+			f.SetLetterSpacing(tt.ls)
+			f.SetAspectRatio(tt.ar)
+			if f != tt.f {
+				t.Errorf("Flags setters = %05b, want %05b", f, tt.f)
+			}
+		})
+	}
+}
+
+func TestFlags_Valid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		f    layout.Flags
+		want bool
+	}{
+		{"zero", 0, true},
+		{"all", 0b11010, true},
+		{"ls reserved", 0b01100, false},
+		{"ar reserved", 0b00011, false},
+		{"unused", 0b100000, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.f.Valid(); (err == nil) != tt.want {
+				t.Errorf("Flags.Valid() error = %v, want valid %v", err, tt.want)
+			}
+		})
+	}
+	if err := layout.Flags(0b01111).Valid(); err == nil ||
+		!strings.Contains(err.Error(), "letter-spacing 11") || !strings.Contains(err.Error(), "aspect ratio 11") {
+		t.Errorf("Flags.Valid() error = %v, want the reserved 11 values", err)
+	}
+}
+
 func Test_LsBit_String(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
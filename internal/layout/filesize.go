@@ -9,7 +9,7 @@ import (
 
 // Sizes is the original file size in multiple formats.
 type Sizes struct {
-	Bytes   uint16 `json:"bytes"   xml:"bytes"`        // bytes as an integer
+	Bytes   uint32 `json:"bytes"   xml:"bytes"`        // bytes as an integer
 	Decimal string `json:"decimal" xml:"decimal,attr"` // decimal is a base 10 value
 	Binary  string `json:"binary"  xml:"binary,attr"`  // binary is a base 2 value
 }
@@ -24,7 +24,6 @@ func (d *Layout) Sizes() Sizes {
 	}
 }
 
-func UnsignedBinary4(b [4]byte) uint16 {
-	//nolint:gosec
-	return uint16(binary.LittleEndian.Uint32(b[:]))
+func UnsignedBinary4(b [4]byte) uint32 {
+	return binary.LittleEndian.Uint32(b[:])
 }
@@ -0,0 +1,41 @@
+// Package walk finds the files of a collection, skipping the hidden files and directories.
+package walk
+
+import (
+	"io/fs"
+	"strings"
+)
+
+// Files calls fn for every regular file of fsys in lexical order.
+// The hidden files and directories, whose names begin with a dot, are skipped.
+// The walk stops at the first error, which is returned.
+func Files(fsys fs.FS, fn func(name string, d fs.DirEntry) error) error {
+	return fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name != "." && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		return fn(name, d)
+	})
+}
+
+// Read calls fn with the content of every file of fsys found by [Files].
+// The walk stops at the first file that cannot be read, and its error is returned.
+func Read(fsys fs.FS, fn func(name string, b []byte)) error {
+	return Files(fsys, func(name string, _ fs.DirEntry) error {
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		fn(name, b)
+		return nil
+	})
+}
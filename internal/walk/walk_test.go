@@ -0,0 +1,54 @@
+package walk_test
+
+import (
+	"errors"
+	"io/fs"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/bengarrett/sauce/internal/walk"
+)
+
+func tree() fstest.MapFS {
+	return fstest.MapFS{
+		"b.ans":           {Data: []byte("b")},
+		"a/c.ans":         {Data: []byte("c")},
+		".hidden":         {Data: []byte("hidden")},
+		".git/config":     {Data: []byte("config")},
+		"a/.cache/d.ans":  {Data: []byte("d")},
+		"link":            {Data: []byte("b.ans"), Mode: fs.ModeSymlink},
+		"empty/.keep/dir": {Mode: fs.ModeDir},
+	}
+}
+
+func TestFiles(t *testing.T) {
+	t.Parallel()
+	names := []string{}
+	err := walk.Files(tree(), func(name string, _ fs.DirEntry) error {
+		names = append(names, name)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a/c.ans", "b.ans"}; !slices.Equal(names, want) {
+		t.Errorf("Files() = %q, want %q", names, want)
+	}
+	errStop := errors.New("stop")
+	err = walk.Files(tree(), func(string, fs.DirEntry) error { return errStop })
+	if !errors.Is(err, errStop) {
+		t.Errorf("Files() error = %v, want %v", err, errStop)
+	}
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+	content := ""
+	if err := walk.Read(tree(), func(_ string, b []byte) { content += string(b) }); err != nil {
+		t.Fatal(err)
+	}
+	if content != "cb" {
+		t.Errorf("Read() content = %q, want %q", content, "cb")
+	}
+}
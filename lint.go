@@ -0,0 +1,84 @@
+package sauce
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+var (
+	ErrNoRecord = errors.New("no sauce record")
+	ErrVersion  = errors.New("sauce version is not 00")
+	ErrDate     = errors.New("sauce date is invalid")
+	ErrFileSize = errors.New("sauce file size does not match the content")
+	ErrDataType = errors.New("sauce data type is unknown")
+	ErrFileType = errors.New("sauce file type is unknown")
+	ErrFlags    = errors.New("sauce ansiflags are invalid")
+	ErrComnt    = errors.New("sauce comment block does not match the comment count")
+)
+
+// Lint returns the problems found with the SAUCE metadata of b,
+// or nil when the record follows the specification.
+//
+// The errors are joined using [errors.Join], and each wraps one of the
+// ErrNoRecord, ErrVersion, ErrDate, ErrFileSize, ErrDataType, ErrFileType,
+// ErrFlags or ErrComnt errors. Only ErrNoRecord is returned when b has no record.
+func Lint(b []byte) error {
+	r := Decode(b)
+	return r.Lint(int64(len(Trim(b))))
+}
+
+// Lint returns the problems found with the record of a file that has
+// the size in bytes of the content without its SAUCE metadata.
+// It is like [Lint] but can be used with the record and size
+// returned by a [TrimReader], without reading the whole file into memory.
+func (r *Record) Lint(size int64) error {
+	if r.ID != ID {
+		return ErrNoRecord
+	}
+	var errs []error
+	if r.Version != Version {
+		errs = append(errs, fmt.Errorf("%w: %q", ErrVersion, r.Version))
+	}
+	switch {
+	case r.Date.Time.IsZero():
+		errs = append(errs, ErrDate)
+	case r.Date.Time.After(time.Now()):
+		errs = append(errs, fmt.Errorf("%w: %s is in the future", ErrDate, r.Date.Value))
+	}
+	if int64(r.FileSize.Bytes) != size {
+		errs = append(errs, fmt.Errorf("%w: %d bytes, want %d", ErrFileSize, r.FileSize.Bytes, size))
+	}
+	errs = append(errs, r.lintTypes()...)
+	if r.Comnt.Count > 0 {
+		switch {
+		case r.Comnt.Index < 0:
+			errs = append(errs, fmt.Errorf("%w: %d lines without a block", ErrComnt, r.Comnt.Count))
+		case len(r.Comnt.Comment) != r.Comnt.Count:
+			errs = append(errs, fmt.Errorf("%w: %d lines, want %d", ErrComnt, len(r.Comnt.Comment), r.Comnt.Count))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// lintTypes returns the problems with the data type, file type and ANSiFlags of the record.
+func (r *Record) lintTypes() []error {
+	if r.Data.Type > layout.Executables {
+		return []error{fmt.Errorf("%w: %d", ErrDataType, r.Data.Type)}
+	}
+	var errs []error
+	// the file type of a binary text is half its character width, so any value is valid
+	if r.Data.Type != layout.BinaryTexts && r.File.Name == "" {
+		errs = append(errs, fmt.Errorf("%w: %d for %s", ErrFileType, r.File.Type, r.Data.Name))
+	}
+	switch r.Data.Type {
+	case layout.Characters, layout.BinaryTexts, layout.XBins:
+		if err := r.Info.Flags.Decimal.Valid(); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrFlags, err))
+		}
+	case layout.Nones, layout.Bitmaps, layout.Vectors, layout.Audios, layout.Archives, layout.Executables:
+	}
+	return errs
+}
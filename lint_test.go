@@ -0,0 +1,64 @@
+package sauce_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/fixture"
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+	const text = "hello world"
+	content := []byte(text)
+	good := fixture.ANSI("hello", "", "", 1996)
+	if err := sauce.Lint(fixture.Tag(t, text, good)); err != nil {
+		t.Errorf("Lint() error = %v, want nil", err)
+	}
+	if err := sauce.Lint(content); !errors.Is(err, sauce.ErrNoRecord) {
+		t.Errorf("Lint() error = %v, want %v", err, sauce.ErrNoRecord)
+	}
+	future := good
+	future.Date.Time = time.Now().AddDate(1, 0, 0)
+	filetype := good
+	filetype.File.Type = 99
+	flags := good
+	flags.Info.Flags = layout.Flags(0b11).Parse()
+	tests := []struct {
+		name string
+		b    []byte
+		want error
+	}{
+		{"future date", fixture.Tag(t, text, future), sauce.ErrDate},
+		{"file type", fixture.Tag(t, text, filetype), sauce.ErrFileType},
+		{"flags", fixture.Tag(t, text, flags), sauce.ErrFlags},
+		{"file size", append([]byte("more "), fixture.Tag(t, text, good)...), sauce.ErrFileSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := sauce.Lint(tt.b); !errors.Is(err, tt.want) {
+				t.Errorf("Lint() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecord_Lint(t *testing.T) {
+	t.Parallel()
+	raw, err := static.ReadFile(example)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := sauce.Decode(raw)
+	err = r.Lint(int64(len(sauce.Trim(raw))))
+	if !errors.Is(err, sauce.ErrFileSize) || !errors.Is(err, sauce.ErrFlags) {
+		t.Errorf("Lint() error = %v, want %v and %v", err, sauce.ErrFileSize, sauce.ErrFlags)
+	}
+	if errors.Is(err, sauce.ErrComnt) || errors.Is(err, sauce.ErrDate) {
+		t.Errorf("Lint() error = %v", err)
+	}
+}
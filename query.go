@@ -0,0 +1,621 @@
+package sauce
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+var (
+	ErrSyntax = errors.New("query syntax error")
+	ErrField  = errors.New("unknown query field")
+	ErrValue  = errors.New("invalid query value")
+	ErrOp     = errors.New("invalid query operator")
+)
+
+// Query is a compiled expression that filters SAUCE records.
+// A Query is safe for concurrent use by multiple goroutines.
+type Query struct {
+	expr  string
+	match func(r *Record) bool
+}
+
+// Compile parses a query expression that is matched against the fields of a SAUCE record,
+// such as:
+//
+//	group = "ACiD" and year between 1994 and 1997 and datatype = characters and comments ~ "greets"
+//
+// A comparison is a field, an operator and a value. The operators are = and != for equality,
+// < <= > and >= for order, ~ and !~ for text that contains the value, "between A and B"
+// for an inclusive range, and "in (A, B, ...)" for a list of values.
+// Comparisons are combined with "and", "or", "not" and parentheses,
+// where "and" takes precedence over "or". Keywords and text are case-insensitive, and values
+// that contain spaces or symbols are quoted with double or single quotes.
+//
+// The fields are:
+//
+//	title, author, group, font    the text fields of the record
+//	comments                      the lines of the comment block
+//	date                          the date in the CCYYMMDD format, such as 19960501
+//	year                          the year of the date, or 0 when it is not set
+//	size                          the file size in bytes, without the SAUCE metadata
+//	datatype                      the data type, such as characters, bitmaps, audio or 1
+//	filetype                      the file type, such as ansi, ascii, png, xm or 1
+//	tinfo1, tinfo2, tinfo3        the type dependent numeric information
+//	flags                         the numeric ANSiFlags value
+//	nonblink                      the non-blink mode, also known as iCE colors, true or false
+//	letterspacing                 the letter-spacing, none, 8px, 9px or reserved
+//	aspectratio                   the aspect ratio, none, stretch, square or reserved
+//	valid                         the record is complete, true or false
+//
+// The data type and file type names are those of the SAUCE specification,
+// or the names of the DataType and FileType of a record, such as "ANSI color text".
+// An empty expression matches every record.
+func Compile(expr string) (*Query, error) {
+	toks, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(toks) == 1 {
+		return &Query{expr: expr, match: func(*Record) bool { return true }}, nil
+	}
+	p := &parser{toks: toks}
+	m, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEnd {
+		return nil, p.errorf(t, "unexpected %q", t.text)
+	}
+	return &Query{expr: expr, match: m}, nil
+}
+
+// MustCompile is like [Compile] but panics if the expression cannot be parsed.
+func MustCompile(expr string) *Query {
+	q, err := Compile(expr)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// Match reports whether the record r matches the query.
+func (q *Query) Match(r *Record) bool {
+	if r == nil {
+		r = &Record{}
+	}
+	return q.match(r)
+}
+
+// String returns the source expression of the query.
+func (q *Query) String() string {
+	return q.expr
+}
+
+// tokKind is the kind of a query token.
+type tokKind int
+
+const (
+	tokEnd    tokKind = iota // end of the expression
+	tokWord                  // word is a field, keyword, number or unquoted value
+	tokString                // string is a quoted value
+	tokOp                    // op is a comparison operator
+	tokLParen                // opening parenthesis
+	tokRParen                // closing parenthesis
+	tokComma                 // comma of a list of values
+)
+
+type token struct {
+	kind tokKind
+	text string
+	pos  int
+}
+
+// lex returns the tokens of the expression, which end with a tokEnd token.
+func lex(expr string) ([]token, error) {
+	toks := []token{}
+	rs := []rune(expr)
+	for i := 0; i < len(rs); {
+		c := rs[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			toks = append(toks, token{tokLParen, "(", i})
+			i++
+		case c == ')':
+			toks = append(toks, token{tokRParen, ")", i})
+			i++
+		case c == ',':
+			toks = append(toks, token{tokComma, ",", i})
+			i++
+		case c == '"' || c == '\'':
+			s, n, err := quoted(rs[i:])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w at %d", ErrSyntax, err, i)
+			}
+			toks = append(toks, token{tokString, s, i})
+			i += n
+		case strings.ContainsRune("=!<>~", c):
+			op := string(c)
+			if i+1 < len(rs) && (rs[i+1] == '=' || (c == '!' && rs[i+1] == '~')) {
+				op += string(rs[i+1])
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("%w: unexpected %q at %d", ErrSyntax, op, i)
+			}
+			toks = append(toks, token{tokOp, op, i})
+			i += len([]rune(op))
+		default:
+			start := i
+			for i < len(rs) && !unicode.IsSpace(rs[i]) && !strings.ContainsRune("()=!<>~,\"'", rs[i]) {
+				i++
+			}
+			toks = append(toks, token{tokWord, string(rs[start:i]), start})
+		}
+	}
+	return append(toks, token{tokEnd, "end of expression", len(rs)}), nil
+}
+
+// quoted returns the text of the quoted string at the start of rs and the number of runes it used.
+// A backslash escapes the quote character or another backslash.
+func quoted(rs []rune) (string, int, error) {
+	q := rs[0]
+	var sb strings.Builder
+	for i := 1; i < len(rs); i++ {
+		switch rs[i] {
+		case '\\':
+			if i+1 < len(rs) {
+				i++
+			}
+			sb.WriteRune(rs[i])
+		case q:
+			return sb.String(), i + 1, nil
+		default:
+			sb.WriteRune(rs[i])
+		}
+	}
+	return "", 0, errors.New("unterminated string")
+}
+
+// parser is a recursive descent parser of the query tokens.
+type parser struct {
+	toks []token
+	i    int
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEnd {
+		p.i++
+	}
+	return t
+}
+
+// keyword reports whether the next token is the keyword, and consumes it.
+func (p *parser) keyword(kw string) bool {
+	if t := p.peek(); t.kind == tokWord && strings.EqualFold(t.text, kw) {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *parser) errorf(t token, format string, a ...any) error {
+	return fmt.Errorf("%w: %s at %d", ErrSyntax, fmt.Sprintf(format, a...), t.pos)
+}
+
+// or parses a sequence of and expressions joined by the or keyword.
+func (p *parser) or() (func(*Record) bool, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(r *Record) bool { return l(r) || right(r) }
+	}
+	return left, nil
+}
+
+// and parses a sequence of unary expressions joined by the and keyword.
+func (p *parser) and() (func(*Record) bool, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(r *Record) bool { return l(r) && right(r) }
+	}
+	return left, nil
+}
+
+// unary parses a negated expression, a parenthesized expression or a comparison.
+func (p *parser) unary() (func(*Record) bool, error) {
+	if p.keyword("not") {
+		m, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(r *Record) bool { return !m(r) }, nil
+	}
+	if p.peek().kind == tokLParen {
+		p.next()
+		m, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokRParen {
+			return nil, p.errorf(t, "expected \")\" but found %q", t.text)
+		}
+		return m, nil
+	}
+	return p.comparison()
+}
+
+// comparison parses a field, an operator and its values.
+func (p *parser) comparison() (func(*Record) bool, error) {
+	t := p.next()
+	if t.kind != tokWord {
+		return nil, p.errorf(t, "expected a field but found %q", t.text)
+	}
+	f, ok := queryFields()[strings.ToLower(t.text)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q at %d", ErrField, t.text, t.pos)
+	}
+	switch {
+	case p.keyword("between"):
+		lo, err := p.value(f)
+		if err != nil {
+			return nil, err
+		}
+		if !p.keyword("and") {
+			return nil, p.errorf(p.peek(), "expected \"and\" but found %q", p.peek().text)
+		}
+		hi, err := p.value(f)
+		if err != nil {
+			return nil, err
+		}
+		return f.between(t.text, lo, hi)
+	case p.keyword("in"):
+		vals, err := p.list(f)
+		if err != nil {
+			return nil, err
+		}
+		return f.in(vals), nil
+	}
+	op := p.next()
+	if op.kind != tokOp {
+		return nil, p.errorf(op, "expected an operator after %q but found %q", t.text, op.text)
+	}
+	v, err := p.value(f)
+	if err != nil {
+		return nil, err
+	}
+	m, err := f.compare(op.text, v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s %s at %d", err, t.text, op.text, op.pos)
+	}
+	return m, nil
+}
+
+// list parses a parenthesized list of comma separated values.
+func (p *parser) list(f queryField) ([]operand, error) {
+	if t := p.next(); t.kind != tokLParen {
+		return nil, p.errorf(t, "expected \"(\" but found %q", t.text)
+	}
+	vals := []operand{}
+	for {
+		v, err := p.value(f)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, v)
+		t := p.next()
+		if t.kind == tokRParen {
+			return vals, nil
+		}
+		if t.kind != tokComma {
+			return nil, p.errorf(t, "expected \",\" or \")\" but found %q", t.text)
+		}
+	}
+}
+
+// value parses a value of the field.
+func (p *parser) value(f queryField) (operand, error) {
+	t := p.next()
+	if t.kind != tokWord && t.kind != tokString {
+		return operand{}, p.errorf(t, "expected a value but found %q", t.text)
+	}
+	v, err := f.operand(t.text)
+	if err != nil {
+		return operand{}, fmt.Errorf("%w at %d", err, t.pos)
+	}
+	return v, nil
+}
+
+// kind is the type of the values of a field.
+type kind int
+
+const (
+	kindText   kind = iota // text is compared case-insensitively
+	kindNumber             // number is an integer
+	kindBool               // bool is true or false
+	kindEnum               // enum is a named value, such as the data type
+)
+
+// queryField is a field of a record that can be queried.
+type queryField struct {
+	kind kind
+	str  func(r *Record) string // str returns the text of the field, or the name of an enum
+	num  func(r *Record) int64  // num returns the number of the field, or the value of an enum
+	// names returns the matcher of an enum value name, or false when the name is unknown.
+	names func(name string) (func(r *Record) bool, bool)
+}
+
+// operand is a value of a comparison.
+type operand struct {
+	s  string
+	n  int64
+	is func(r *Record) bool // is matches an enum value
+}
+
+// operand returns the value s of the field.
+func (f queryField) operand(s string) (operand, error) {
+	switch f.kind {
+	case kindNumber:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return operand{}, fmt.Errorf("%w: %q is not a number", ErrValue, s)
+		}
+		return operand{s: s, n: n}, nil
+	case kindBool:
+		b, err := strconv.ParseBool(strings.ToLower(s))
+		if err != nil {
+			return operand{}, fmt.Errorf("%w: %q is not true or false", ErrValue, s)
+		}
+		n := int64(0)
+		if b {
+			n = 1
+		}
+		return operand{s: s, n: n}, nil
+	case kindEnum:
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return operand{s: s, n: n, is: func(r *Record) bool { return f.num(r) == n }}, nil
+		}
+		if is, ok := f.names(strings.ToLower(s)); ok {
+			return operand{s: s, is: is}, nil
+		}
+		return operand{s: s, is: func(r *Record) bool { return strings.EqualFold(f.str(r), s) }}, nil
+	case kindText:
+	}
+	return operand{s: s}, nil
+}
+
+// compare returns the matcher of the field, the operator and the value.
+func (f queryField) compare(op string, v operand) (func(*Record) bool, error) {
+	switch op {
+	case "~", "!~":
+		if f.kind == kindNumber || f.kind == kindBool {
+			return nil, ErrOp
+		}
+		want := op == "~"
+		sub := strings.ToLower(v.s)
+		return func(r *Record) bool {
+			return strings.Contains(strings.ToLower(f.str(r)), sub) == want
+		}, nil
+	case "=", "!=":
+		want := op == "="
+		eq := f.equal(v)
+		return func(r *Record) bool { return eq(r) == want }, nil
+	case "<", "<=", ">", ">=":
+		if f.kind == kindBool || f.kind == kindEnum {
+			return nil, ErrOp
+		}
+		return func(r *Record) bool {
+			n := f.order(r, v)
+			switch op {
+			case "<":
+				return n < 0
+			case "<=":
+				return n <= 0
+			case ">":
+				return n > 0
+			default:
+				return n >= 0
+			}
+		}, nil
+	}
+	return nil, ErrOp
+}
+
+// between returns the matcher of an inclusive range of values.
+func (f queryField) between(name string, lo, hi operand) (func(*Record) bool, error) {
+	if f.kind == kindBool || f.kind == kindEnum {
+		return nil, fmt.Errorf("%w: %s between", ErrOp, name)
+	}
+	return func(r *Record) bool {
+		return f.order(r, lo) >= 0 && f.order(r, hi) <= 0
+	}, nil
+}
+
+// in returns the matcher of a list of values.
+func (f queryField) in(vals []operand) func(*Record) bool {
+	eqs := make([]func(*Record) bool, len(vals))
+	for i, v := range vals {
+		eqs[i] = f.equal(v)
+	}
+	return func(r *Record) bool {
+		return slices.ContainsFunc(eqs, func(eq func(*Record) bool) bool { return eq(r) })
+	}
+}
+
+// equal returns the matcher of a field that is equal to the value.
+func (f queryField) equal(v operand) func(*Record) bool {
+	switch f.kind {
+	case kindNumber, kindBool:
+		return func(r *Record) bool { return f.num(r) == v.n }
+	case kindEnum:
+		return v.is
+	case kindText:
+	}
+	return func(r *Record) bool { return strings.EqualFold(strings.TrimSpace(f.str(r)), v.s) }
+}
+
+// order compares the field of the record to the value,
+// returning -1 when it is less, 0 when it is equal and +1 when it is greater.
+func (f queryField) order(r *Record, v operand) int {
+	if f.kind == kindNumber {
+		n := f.num(r)
+		switch {
+		case n < v.n:
+			return -1
+		case n > v.n:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(strings.ToLower(f.str(r)), strings.ToLower(v.s))
+}
+
+// queryFields returns the queryable fields of a record by name.
+func queryFields() map[string]queryField {
+	str := func(get func(r *Record) string) queryField { return queryField{kind: kindText, str: get} }
+	num := func(get func(r *Record) int64) queryField { return queryField{kind: kindNumber, num: get} }
+	flag := func(get func(r *Record) bool) queryField {
+		return queryField{kind: kindBool, num: func(r *Record) int64 {
+			if get(r) {
+				return 1
+			}
+			return 0
+		}}
+	}
+	return map[string]queryField{
+		"title":  str(func(r *Record) string { return r.Title }),
+		"author": str(func(r *Record) string { return r.Author }),
+		"group":  str(func(r *Record) string { return r.Group }),
+		"font":   str(func(r *Record) string { return r.Info.Font }),
+		"date":   str(func(r *Record) string { return r.Date.Value }),
+		"comments": str(func(r *Record) string {
+			lines := make([]string, len(r.Comnt.Comment))
+			for i, line := range r.Comnt.Comment {
+				lines[i] = strings.TrimRight(line, " ")
+			}
+			return strings.Join(lines, "\n")
+		}),
+		"year": num(func(r *Record) int64 {
+			if r.Date.Time.IsZero() {
+				return 0
+			}
+			return int64(r.Date.Time.Year())
+		}),
+		"size":     num(func(r *Record) int64 { return int64(r.FileSize.Bytes) }),
+		"tinfo1":   num(func(r *Record) int64 { return int64(r.Info.Info1.Value) }),
+		"tinfo2":   num(func(r *Record) int64 { return int64(r.Info.Info2.Value) }),
+		"tinfo3":   num(func(r *Record) int64 { return int64(r.Info.Info3.Value) }),
+		"flags":    num(func(r *Record) int64 { return int64(r.Info.Flags.Decimal) }),
+		"nonblink": flag(func(r *Record) bool { return r.Info.Flags.NonBlink() }),
+		"valid":    flag(func(r *Record) bool { return r.Valid() }),
+		"datatype": {
+			kind:  kindEnum,
+			str:   func(r *Record) string { return r.Data.Name },
+			num:   func(r *Record) int64 { return int64(r.Data.Type) },
+			names: dataTypes,
+		},
+		"filetype": {
+			kind:  kindEnum,
+			str:   func(r *Record) string { return r.File.Name },
+			num:   func(r *Record) int64 { return int64(r.File.Type) },
+			names: fileTypes,
+		},
+		"letterspacing": {
+			kind: kindEnum,
+			str:  func(r *Record) string { return r.Info.Flags.LetterSpacing().String() },
+			num:  func(r *Record) int64 { return int64(r.Info.Flags.LetterSpacing()) },
+			names: func(name string) (func(*Record) bool, bool) {
+				i := slices.Index([]string{"none", "8px", "9px", "reserved"}, name)
+				return func(r *Record) bool { return int(r.Info.Flags.LetterSpacing()) == i }, i >= 0
+			},
+		},
+		"aspectratio": {
+			kind: kindEnum,
+			str:  func(r *Record) string { return r.Info.Flags.AspectRatio().String() },
+			num:  func(r *Record) int64 { return int64(r.Info.Flags.AspectRatio()) },
+			names: func(name string) (func(*Record) bool, bool) {
+				i := slices.Index([]string{"none", "stretch", "square", "reserved"}, name)
+				return func(r *Record) bool { return int(r.Info.Flags.AspectRatio()) == i }, i >= 0
+			},
+		},
+	}
+}
+
+// dataTypes returns the matcher of a data type name of the SAUCE specification,
+// which may also be the plural or singular form of the name.
+func dataTypes(name string) (func(*Record) bool, bool) {
+	names := []string{"none", "character", "bitmap", "vector", "audio", "binarytext", "xbin", "archive", "executable"}
+	i := slices.Index(names, strings.TrimSuffix(name, "s"))
+	if i < 0 {
+		return nil, false
+	}
+	dt := layout.TypeOfData(i) //nolint:gosec
+	return func(r *Record) bool { return r.Data.Type == dt }, true
+}
+
+// fileTypes returns the matcher of a file type name of the SAUCE specification,
+// which also matches the data type of the file type.
+func fileTypes(name string) (func(*Record) bool, bool) {
+	type pair struct {
+		dt layout.TypeOfData
+		ft layout.TypeOfFile
+	}
+	types := map[layout.TypeOfData][]string{
+		layout.Characters: {
+			"ascii", "ansi", "ansimation", "rip", "pcboard", "avatar", "html", "source", "tundradraw",
+		},
+		layout.Bitmaps: {
+			"gif", "pcx", "lbm", "tga", "fli", "flc", "bmp", "gl", "dl", "wpg", "png", "jpg", "mpg", "avi",
+		},
+		layout.Vectors: {"dxf", "dwg", "wpg", "3ds"},
+		layout.Audios: {
+			"mod", "669", "stm", "s3m", "mtm", "far", "ult", "amf", "dmf", "okt", "rol", "cmf", "mid",
+			"sadt", "voc", "wav", "smp8", "smp8s", "smp16", "smp16s", "patch8", "patch16", "xm", "hsc", "it",
+		},
+		layout.BinaryTexts: {"bin"},
+		layout.XBins:       {"xbin"},
+		layout.Archives:    {"zip", "arj", "lzh", "arc", "tar", "zoo", "rar", "uc2", "pak", "sqz"},
+		layout.Executables: {"exe"},
+	}
+	pairs := []pair{}
+	for dt, names := range types {
+		if i := slices.Index(names, name); i >= 0 {
+			pairs = append(pairs, pair{dt, layout.TypeOfFile(i)}) //nolint:gosec
+		}
+	}
+	if len(pairs) == 0 {
+		return nil, false
+	}
+	return func(r *Record) bool {
+		return slices.ContainsFunc(pairs, func(p pair) bool {
+			// the file type of a binary text is its character width, so any value matches
+			return r.Data.Type == p.dt && (r.File.Type == p.ft || p.dt == layout.BinaryTexts)
+		})
+	}, true
+}
@@ -0,0 +1,118 @@
+package sauce_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/fixture"
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+func queryRecord() sauce.Record {
+	r := fixture.ANSI("Logo", "Zed", "ACiD Productions", 1995)
+	r.Date = layout.Dates{Value: "19950601", Time: time.Date(1995, 6, 1, 0, 0, 0, 0, time.UTC)}
+	r.FileSize = layout.Sizes{Bytes: 4096}
+	r.Info = layout.Infos{
+		Info1: layout.Info{Value: 80},
+		Flags: layout.Flags(0b10011).Parse(),
+		Font:  "IBM VGA",
+	}
+	r.Comnt = layout.Comment{Comment: []string{"Greets to the ACiD crew   "}}
+	return r
+}
+
+func TestQuery_Match(t *testing.T) {
+	t.Parallel()
+	r := queryRecord()
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{``, true},
+		{`group = "ACiD Productions" and year between 1994 and 1997 and datatype = characters and comments ~ "greets"`, true},
+		{`group = acid`, false},
+		{`group ~ acid`, true},
+		{`group !~ acid`, false},
+		{`title != logo`, false},
+		{`author in (amy, zed)`, true},
+		{`year in (1994, 1996)`, false},
+		{`year >= 1995 and year < 1996`, true},
+		{`year > 1995 or size <= 4096`, true},
+		{`not (year > 1995 or size < 4096)`, true},
+		{`date between 19950101 and 19951231`, true},
+		{`datatype = 1 and datatype = "text or character stream" and datatype = character`, true},
+		{`datatype = bitmaps`, false},
+		{`filetype = ansi and filetype = 1 and filetype ~ "color"`, true},
+		{`filetype in (ascii, png)`, false},
+		{`tinfo1 = 80 and tinfo2 = 0`, true},
+		{`nonblink = true and letterspacing = none and aspectratio = reserved`, true},
+		{`flags = 19 and valid = true and font = 'ibm vga'`, true},
+		{`TITLE = "Logo" AND Author = "ZED"`, true},
+		{`title = "say \"hi\""`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			t.Parallel()
+			q, err := sauce.Compile(tt.expr)
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+			if got := q.Match(&r); got != tt.want {
+				t.Errorf("Match() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+	if sauce.MustCompile(`year = 0 and valid = false`).Match(nil) != true {
+		t.Error("Match(nil) = false, want true")
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		expr string
+		want error
+	}{
+		{`title`, sauce.ErrSyntax},
+		{`title = `, sauce.ErrSyntax},
+		{`title = "open`, sauce.ErrSyntax},
+		{`(title = a`, sauce.ErrSyntax},
+		{`title = a b`, sauce.ErrSyntax},
+		{`title ! a`, sauce.ErrSyntax},
+		{`year between 1990 1999`, sauce.ErrSyntax},
+		{`author in (a, b`, sauce.ErrSyntax},
+		{`colour = red`, sauce.ErrField},
+		{`year = ninety`, sauce.ErrValue},
+		{`valid = maybe`, sauce.ErrValue},
+		{`year ~ 199`, sauce.ErrOp},
+		{`datatype > 1`, sauce.ErrOp},
+		{`nonblink between true and false`, sauce.ErrOp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			t.Parallel()
+			if _, err := sauce.Compile(tt.expr); !errors.Is(err, tt.want) {
+				t.Errorf("Compile() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func ExampleCompile() {
+	raw, err := static.ReadFile(example)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	r := sauce.Decode(raw)
+	q, err := sauce.Compile(`author ~ "sauce" and year between 2010 and 2019 and filetype = ascii`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(q.Match(&r))
+	// Output: true
+}
@@ -0,0 +1,93 @@
+package sauce
+
+import (
+	"io"
+
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+// tailSize is the maximum length of the SAUCE metadata, which is the
+// end-of-file marker, the comment block of 255 lines and the SAUCE record,
+// plus a line of content as the comment block is only found after the first line.
+const tailSize = layout.ComntLineSize + 1 + len(layout.ComntID) + layout.ComntLineSize*layout.ComntMaxLines + 128
+
+// TrimReader is a reader that removes any SAUCE metadata and the optional
+// end-of-file marker from the end of the content of another reader.
+type TrimReader struct {
+	r    io.Reader
+	tail []byte // tail is the read content that is held back
+	buf  []byte // buf is used to read from r
+	eof  bool   // eof is set once r has returned io.EOF
+	err  error  // err is the first read error of r
+	off  int    // off is the number of bytes of content that have been read
+	rec  Record
+}
+
+// NewTrimReader returns a reader of r without any SAUCE metadata.
+//
+// Unlike [Trim], the content of r is passed through as it is read,
+// and only the last 16,518 bytes, which is more than the maximum length of
+// a SAUCE record with its comment block and end-of-file marker, are held back.
+// The metadata is removed once r is read to the end, and its
+// decoded SAUCE record is returned by [TrimReader.Record].
+func NewTrimReader(r io.Reader) *TrimReader {
+	const size = 32 * 1024
+	return &TrimReader{r: r, buf: make([]byte, size), rec: Decode(nil)}
+}
+
+// Read reads up to len(p) bytes of the content into p.
+func (t *TrimReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for !t.eof && t.err == nil && len(t.tail) < tailSize+len(p) {
+		n, err := t.r.Read(t.buf)
+		t.tail = append(t.tail, t.buf[:n]...)
+		switch {
+		case err == io.EOF:
+			t.eof = true
+			t.rec = Decode(t.tail)
+			t.rec.offset(t.off)
+			t.tail = Trim(t.tail)
+		case err != nil:
+			t.err = err
+		case n == 0:
+			// avoid a busy loop with a reader that returns nothing
+			return 0, nil
+		}
+	}
+	avail := len(t.tail)
+	if !t.eof {
+		avail -= tailSize
+	}
+	if avail <= 0 {
+		if t.err != nil {
+			return 0, t.err
+		}
+		return 0, io.EOF
+	}
+	n := copy(p, t.tail[:avail])
+	t.tail = t.tail[:copy(t.tail, t.tail[n:])]
+	t.off += n
+	return n, nil
+}
+
+// Record returns the SAUCE record that was removed from the content.
+// It is an empty record until the reader has been read to the end,
+// or when the content has no SAUCE metadata.
+// The Index fields of the record are the positions within the whole of the read content.
+func (t *TrimReader) Record() Record {
+	return t.rec
+}
+
+// offset adds n to the positions of a record that was decoded from the last bytes of a file,
+// which are found at offset n, so the Index fields are the positions within the whole file.
+func (r *Record) offset(n int) {
+	const none = -1
+	if r.Index > none {
+		r.Index += n
+	}
+	if r.Comnt.Index > none {
+		r.Comnt.Index += n
+	}
+}
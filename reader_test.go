@@ -0,0 +1,111 @@
+package sauce_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/bengarrett/sauce"
+)
+
+func TestTrimReader(t *testing.T) {
+	t.Parallel()
+	raw, err := static.ReadFile(example)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// large content that is longer than the held back tail
+	large := append(bytes.Repeat([]byte("0123456789abcdef"), 4096), raw...)
+	tests := []struct {
+		name string
+		b    []byte
+		r    func(io.Reader) io.Reader
+	}{
+		{"example", raw, func(r io.Reader) io.Reader { return r }},
+		{"one byte", raw, iotest.OneByteReader},
+		{"half", large, iotest.HalfReader},
+		{"large", large, func(r io.Reader) io.Reader { return r }},
+		{"no sauce", []byte("hello world"), iotest.DataErrReader},
+		{"record only", raw[len(raw)-128:], func(r io.Reader) io.Reader { return r }},
+		{"empty", nil, func(r io.Reader) io.Reader { return r }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tr := sauce.NewTrimReader(tt.r(bytes.NewReader(tt.b)))
+			got, err := io.ReadAll(tr)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if want := sauce.Trim(tt.b); !bytes.Equal(got, want) {
+				t.Errorf("TrimReader read %d bytes, want %d", len(got), len(want))
+			}
+			rec, want := tr.Record(), sauce.Decode(tt.b)
+			if rec.Title != want.Title || rec.Valid() != want.Valid() {
+				t.Errorf("TrimReader.Record() = %q, want %q", rec.Title, want.Title)
+			}
+			if rec.Index != want.Index || rec.Comnt.Index != want.Comnt.Index {
+				t.Errorf("TrimReader.Record() index = %d, %d, want %d, %d",
+					rec.Index, rec.Comnt.Index, want.Index, want.Comnt.Index)
+			}
+		})
+	}
+}
+
+func TestTrimReader_Comments(t *testing.T) {
+	t.Parallel()
+	raw, err := static.ReadFile(example)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const lines, comments = 255, 104
+	record := bytes.Clone(raw[len(raw)-128:])
+	record[comments] = lines
+	content := bytes.Repeat([]byte("0123456789abcdef"), 2048)
+	b := bytes.Clone(content)
+	b = append(b, sauce.EOF)
+	b = append(b, "COMNT"...)
+	b = append(b, bytes.Repeat([]byte("a comment line "), lines*64/15)...)
+	b = append(b, record...)
+	tr := sauce.NewTrimReader(bytes.NewReader(b))
+	// one byte reads hold back exactly the tail before the end of the content is reached
+	got, err := io.ReadAll(iotest.OneByteReader(tr))
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("TrimReader read %d bytes, want %d", len(got), len(content))
+	}
+	r := tr.Record()
+	if len(r.Comnt.Comment) != lines {
+		t.Errorf("TrimReader.Record() comments = %d lines, want %d", len(r.Comnt.Comment), lines)
+	}
+	if want := len(content) + 1; r.Comnt.Index != want {
+		t.Errorf("TrimReader.Record() comment index = %d, want %d", r.Comnt.Index, want)
+	}
+	if want := len(b) - len(record); r.Index != want {
+		t.Errorf("TrimReader.Record() index = %d, want %d", r.Index, want)
+	}
+}
+
+func TestTrimReader_Error(t *testing.T) {
+	t.Parallel()
+	errRead := errors.New("read error")
+	tr := sauce.NewTrimReader(iotest.ErrReader(errRead))
+	if _, err := io.ReadAll(tr); !errors.Is(err, errRead) {
+		t.Errorf("ReadAll() error = %v, want %v", err, errRead)
+	}
+}
+
+func TestTrim_RecordOnly(t *testing.T) {
+	t.Parallel()
+	raw, err := static.ReadFile(example)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sauce.Trim(raw[len(raw)-128:]); len(got) != 0 {
+		t.Errorf("Trim() = %d bytes, want 0", len(got))
+	}
+}
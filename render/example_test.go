@@ -0,0 +1,6 @@
+package render_test
+
+import "embed"
+
+//go:embed static/*
+var static embed.FS
@@ -0,0 +1,182 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"html/template"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/humanize"
+	"github.com/bengarrett/sauce/internal/cp437"
+	"github.com/bengarrett/sauce/screen"
+)
+
+// page is the HTML document of the artwork and its metadata panel.
+const page = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { background: #111; color: #ccc; font-family: sans-serif; }
+main { display: flex; flex-wrap: wrap; gap: 2em; align-items: flex-start; }
+pre.art { margin: 0; font-family: "Perfect DOS VGA 437", "Px437 IBM VGA 8x16", monospace; line-height: 1; background: #000; }
+pre.art.ninepx { letter-spacing: 0.125ch; }
+aside.sauce dt { font-weight: bold; }
+aside.sauce dd { margin: 0 0 0.5em 0; }
+aside.sauce pre { margin: 0; }
+</style>
+</head>
+<body>
+<main>
+{{.Pre}}
+{{.Panel}}
+</main>
+</body>
+</html>
+`
+
+// panel is the metadata panel of a SAUCE record.
+const panel = `<aside class="sauce">
+<dl>
+{{- range .}}
+<dt>{{.Name}}</dt>
+<dd>{{if .Pre}}<pre>{{.Value}}</pre>{{else}}{{.Value}}{{end}}</dd>
+{{- end}}
+</dl>
+</aside>`
+
+// HTML plays back the artwork b and writes it to w as a HTML document,
+// with a metadata panel of the SAUCE record of b, when present.
+// The date of the record is formatted using the layout.
+func HTML(w io.Writer, b []byte, date humanize.Layout) error {
+	r := sauce.Decode(b)
+	s, opts, err := Load(b, &r)
+	if err != nil {
+		return err
+	}
+	var pre, meta bytes.Buffer
+	if err := Pre(&pre, s, opts); err != nil {
+		return err
+	}
+	if r.Valid() {
+		if err := Panel(&meta, &r, date); err != nil {
+			return err
+		}
+	}
+	title := strings.TrimSpace(text(r.Title))
+	if title == "" {
+		title = "Untitled"
+	}
+	t := template.Must(template.New("page").Parse(page))
+	if err := t.Execute(w, struct {
+		Title string
+		Pre   template.HTML
+		Panel template.HTML
+	}{
+		Title: title,
+		Pre:   template.HTML(pre.String()),  //nolint:gosec
+		Panel: template.HTML(meta.String()), //nolint:gosec
+	}); err != nil {
+		return fmt.Errorf("html page: %w", err)
+	}
+	return nil
+}
+
+// Pre writes the character cells of the screen s to w as a HTML pre element
+// of colored spans, with the CP437 characters translated to Unicode.
+//
+// Blinking text displays a high intensity background in non-blink mode.
+// The 9 pixel letter-spacing is simulated using a CSS class that widens the characters.
+func Pre(w io.Writer, s *screen.Screen, opts Options) error {
+	pal := opts.palette()
+	var buf bytes.Buffer
+	buf.WriteString(`<pre class="art`)
+	if opts.NinePx {
+		buf.WriteString(` ninepx`)
+	}
+	buf.WriteString(`">`)
+	for y := range s.Height() {
+		row := s.Row(y)
+		end := len(row)
+		for end > 0 && blank(row[end-1]) {
+			end--
+		}
+		prev := ""
+		for _, cell := range row[:end] {
+			fg, bg := cell.Attr.Colors(opts.NonBlink)
+			if style := css(pal, fg, bg); style != prev {
+				if prev != "" {
+					buf.WriteString(`</span>`)
+				}
+				fmt.Fprintf(&buf, `<span style="%s">`, style)
+				prev = style
+			}
+			buf.WriteString(html.EscapeString(string(cp437.Rune(cell.Char))))
+		}
+		if prev != "" {
+			buf.WriteString(`</span>`)
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteString(`</pre>`)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("html pre: %w", err)
+	}
+	return nil
+}
+
+// Panel writes the title, author, group, date, size, font and comments
+// of the SAUCE record r to w as a HTML aside element.
+// The date is formatted using the layout, and the CP437 text is translated to Unicode.
+func Panel(w io.Writer, r *sauce.Record, date humanize.Layout) error {
+	if r == nil {
+		return nil
+	}
+	type item struct {
+		Name  string
+		Value string
+		Pre   bool
+	}
+	items := []item{}
+	add := func(name, value string, pre bool) {
+		if strings.TrimSpace(value) != "" {
+			items = append(items, item{Name: name, Value: value, Pre: pre})
+		}
+	}
+	add("Title", text(r.Title), false)
+	add("Author", text(r.Author), false)
+	add("Group", text(r.Group), false)
+	if !r.Date.Time.IsZero() {
+		add("Date", date.Format(r.Date.Time), false)
+	}
+	add("Size", r.FileSize.Decimal, false)
+	add("Font", r.Info.Font, false)
+	lines := make([]string, len(r.Comnt.Comment))
+	for i, line := range r.Comnt.Comment {
+		lines[i] = strings.TrimRight(text(line), " ")
+	}
+	add("Comments", strings.Join(lines, "\n"), true)
+	t := template.Must(template.New("panel").Parse(panel))
+	if err := t.Execute(w, items); err != nil {
+		return fmt.Errorf("html panel: %w", err)
+	}
+	return nil
+}
+
+// css returns the inline style of the foreground and background colors.
+func css(pal color.Palette, fg, bg screen.Color) string {
+	f, b := rgba(pal, fg), rgba(pal, bg)
+	return fmt.Sprintf("color:#%02x%02x%02x;background-color:#%02x%02x%02x",
+		f.R, f.G, f.B, b.R, b.G, b.B)
+}
+
+// text returns the CP437 text of a SAUCE record field as a UTF-8 string.
+func text(s string) string {
+	return cp437.String([]byte(s))
+}
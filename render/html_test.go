@@ -0,0 +1,100 @@
+package render_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/humanize"
+	"github.com/bengarrett/sauce/render"
+	"github.com/bengarrett/sauce/screen"
+)
+
+func TestPre(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		b    string
+		opts render.Options
+		want string
+	}{
+		{"plain", "<\xdb>", render.Options{},
+			`<pre class="art"><span style="color:#aaaaaa;background-color:#000000">&lt;█&gt;</span>` + "\n</pre>"},
+		{"colors", "\x1b[1;31mA\x1b[0mB", render.Options{},
+			`<pre class="art"><span style="color:#ff5555;background-color:#000000">A</span>` +
+				`<span style="color:#aaaaaa;background-color:#000000">B</span>` + "\n</pre>"},
+		{"ice", "\x1b[5;44mA", render.Options{NonBlink: true, NinePx: true},
+			`<pre class="art ninepx"><span style="color:#aaaaaa;background-color:#5555ff">A</span>` + "\n</pre>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			if err := render.Pre(&buf, screen.Load([]byte(tt.b), 0), tt.opts); err != nil {
+				t.Error(err)
+				return
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Pre() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPanel(t *testing.T) {
+	t.Parallel()
+	r := sauce.Record{Title: "<Hello>", Author: "\x8eric", Group: "Group"}
+	r.Comnt.Comment = []string{"line one  ", "line two \xdb\xb1"}
+	var buf bytes.Buffer
+	if err := render.Panel(&buf, &r, humanize.DMY); err != nil {
+		t.Error(err)
+		return
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"<dt>Title</dt>\n<dd>&lt;Hello&gt;</dd>",
+		"<dt>Author</dt>\n<dd>Äric</dd>",
+		"<dt>Group</dt>",
+		"<dd><pre>line one\nline two █▒</pre></dd>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Panel() = %q, is missing %q", got, want)
+		}
+	}
+	if !utf8.ValidString(got) {
+		t.Errorf("Panel() = %q, want valid UTF-8", got)
+	}
+	buf.Reset()
+	if err := render.Panel(&buf, &sauce.Record{Title: "Title"}, humanize.DMY); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "Author") {
+		t.Errorf("Panel() = %q, want no empty author", &buf)
+	}
+}
+
+func TestHTML(t *testing.T) {
+	t.Parallel()
+	b, err := static.ReadFile("static/sauce.txt")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var buf bytes.Buffer
+	if err := render.HTML(&buf, b, humanize.DMY); err != nil {
+		t.Error(err)
+		return
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"<title>Sauce title</title>",
+		`<pre class="art">`,
+		"<dd>26 Nov 2016</dd>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTML() is missing %q", want)
+		}
+	}
+}
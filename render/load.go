@@ -0,0 +1,235 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image/color"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/ansi"
+	"github.com/bengarrett/sauce/internal/font"
+	"github.com/bengarrett/sauce/internal/layout"
+	"github.com/bengarrett/sauce/screen"
+)
+
+var (
+	ErrUnsupported = errors.New("unsupported data or file type")
+	ErrXBin        = errors.New("invalid xbin data")
+)
+
+// binWidth is the default number of columns of a binary text file.
+const binWidth = 160
+
+// Load plays back the artwork b on a screen, using the data type and file type
+// of the SAUCE record r, and returns the screen with the display settings of the record.
+// Artwork without a SAUCE record, where r is nil or empty, is played back as ANSI text.
+//
+// The screen width is the SAUCE TInfo1 character width of the text,
+// or 80 columns when it is not set. A width of more than 80 columns is limited
+// to the length of the text, which is the widest line the text can fill.
+func Load(b []byte, r *sauce.Record) (*screen.Screen, Options, error) {
+	opts := NewOptions(r)
+	b = sauce.Trim(b)
+	if r == nil {
+		return screen.Load(b, screen.Columns), opts, nil
+	}
+	switch r.Data.Type {
+	case layout.Nones:
+		return screen.Load(b, screen.Columns), opts, nil
+	case layout.Characters:
+		switch layout.Character(r.File.Type) {
+		case layout.ASCII, layout.Ansi, layout.AnsiMation, layout.Source:
+			return screen.Load(b, textWidth(b, r)), opts, nil
+		case layout.PCBoard:
+			s := screen.New(textWidth(b, r))
+			s.Play(ansi.PCBoard(b))
+			return s, opts, nil
+		case layout.Avatar:
+			s := screen.New(textWidth(b, r))
+			s.Play(ansi.Avatar(b))
+			return s, opts, nil
+		case layout.TundraDraw:
+			s, err := Tundra(b)
+			return s, opts, err
+		default:
+		}
+	case layout.BinaryTexts:
+		// the binary text file type is half the character width
+		width := int(r.File.Type) * 2
+		if width == 0 {
+			width = binWidth
+		}
+		return Binary(b, width), opts, nil
+	case layout.XBins:
+		s, xopts, err := XBin(b)
+		if err != nil {
+			return nil, opts, err
+		}
+		xopts.NinePx, xopts.Stretch = opts.NinePx, opts.Stretch
+		if xopts.Glyphs == nil {
+			xopts.Font = opts.Font
+		}
+		return s, xopts, nil
+	default:
+	}
+	return nil, opts, fmt.Errorf("%w: %s, %s", ErrUnsupported, r.Data.Name, r.File.Name)
+}
+
+// textWidth returns the SAUCE TInfo1 character width of the record r,
+// so an untrusted width does not create a screen much wider than the text b.
+func textWidth(b []byte, r *sauce.Record) int {
+	width := int(r.Info.Info1.Value)
+	if width <= screen.Columns {
+		return width
+	}
+	return max(screen.Columns, min(width, len(b)))
+}
+
+// Binary returns the screen of the binary text b, which is a raw memory copy
+// of a text mode screen of character and DOS attribute byte pairs.
+func Binary(b []byte, width int) *screen.Screen {
+	s := screen.New(width)
+	const pair = 2
+	for i := 0; i+1 < len(b); i += pair {
+		n := i / pair
+		s.Put(n%s.Width(), n/s.Width(), screen.Cell{Char: b[i], Attr: screen.DOS(b[i+1])})
+	}
+	return s
+}
+
+// XBin returns the screen of the XBin extended binary text b,
+// with the display settings of its custom palette, font and non-blink mode.
+//
+// See http://www.acid.org/info/xbin/x_spec.htm
+func XBin(b []byte) (*screen.Screen, Options, error) {
+	const (
+		id       = "XBIN\x1a"
+		header   = 11
+		palette  = 1 << 0
+		hasFont  = 1 << 1
+		compress = 1 << 2
+		nonBlink = 1 << 3
+		chars512 = 1 << 4
+		palSize  = 48
+	)
+	opts := Options{}
+	if len(b) < header || !bytes.HasPrefix(b, []byte(id)) {
+		return nil, opts, fmt.Errorf("%w: header", ErrXBin)
+	}
+	width := int(binary.LittleEndian.Uint16(b[5:7]))
+	height := int(binary.LittleEndian.Uint16(b[7:9]))
+	fontSize, flags := int(b[9]), b[10]
+	if width == 0 {
+		return nil, opts, fmt.Errorf("%w: zero width", ErrXBin)
+	}
+	if width > screen.MaxColumns || height > screen.MaxLines || width*height > screen.MaxCells {
+		return nil, opts, fmt.Errorf("%w: %dx%d is larger than a screen", ErrXBin, width, height)
+	}
+	opts.NonBlink = flags&nonBlink != 0
+	i := header
+	if flags&palette != 0 {
+		if len(b) < i+palSize {
+			return nil, opts, fmt.Errorf("%w: palette", ErrXBin)
+		}
+		opts.Palette = xbinPalette(b[i : i+palSize])
+		i += palSize
+	}
+	if flags&hasFont != 0 {
+		glyphs := font.Glyphs
+		if flags&chars512 != 0 {
+			glyphs *= 2
+		}
+		size := fontSize * glyphs
+		if size == 0 || len(b) < i+size {
+			return nil, opts, fmt.Errorf("%w: font", ErrXBin)
+		}
+		// only the first 256 glyphs of a 512 character font are used
+		opts.Glyphs = b[i : i+fontSize*font.Glyphs]
+		i += size
+	}
+	data := b[i:]
+	if flags&compress != 0 {
+		data = xbinExpand(data, width*height)
+	}
+	s := screen.New(width)
+	const pair = 2
+	for n := 0; n < width*height && n*pair+1 < len(data); n++ {
+		s.Put(n%width, n/width, screen.Cell{Char: data[n*pair], Attr: screen.DOS(data[n*pair+1])})
+	}
+	return s, opts, nil
+}
+
+// xbinPalette returns the 16 colors of the XBin palette b, which uses
+// 6-bit red, green and blue values in the DOS color order.
+func xbinPalette(b []byte) color.Palette {
+	const opaque = 0xff
+	scale := func(v byte) byte {
+		v &= 0x3f
+		return v<<2 | v>>4
+	}
+	// the DOS color index of each ANSI color
+	order := [...]int{0, 4, 2, 6, 1, 5, 3, 7}
+	pal := make(color.Palette, colors)
+	for i := range colors {
+		dos := order[i%8] + i/8*8
+		p := b[dos*3 : dos*3+3]
+		pal[i] = color.RGBA{scale(p[0]), scale(p[1]), scale(p[2]), opaque}
+	}
+	return pal
+}
+
+// xbinExpand returns the character and attribute pairs of the compressed XBin data b,
+// which is a sequence of run-length encoded chunks.
+func xbinExpand(b []byte, cells int) []byte {
+	const (
+		none    = 0
+		charRun = 1
+		attrRun = 2
+		bothRun = 3
+		pair    = 2
+		maxRun  = 64 // maxrun is the longest run of a chunk
+	)
+	// each chunk is a run of at most 64 pairs, so the capacity is limited by what the input can expand to
+	out := make([]byte, 0, min(cells*pair, len(b)*maxRun*pair))
+	for i := 0; i < len(b) && len(out) < cells*pair; {
+		kind, count := b[i]>>6, int(b[i]&0x3f)+1
+		i++
+		switch kind {
+		case none:
+			n := min(count*pair, len(b)-i)
+			out = append(out, b[i:i+n]...)
+			i += n
+		case charRun:
+			if i >= len(b) {
+				return out
+			}
+			c := b[i]
+			i++
+			for ; count > 0 && i < len(b); count-- {
+				out = append(out, c, b[i])
+				i++
+			}
+		case attrRun:
+			if i >= len(b) {
+				return out
+			}
+			a := b[i]
+			i++
+			for ; count > 0 && i < len(b); count-- {
+				out = append(out, b[i], a)
+				i++
+			}
+		case bothRun:
+			if i+1 >= len(b) {
+				return out
+			}
+			for ; count > 0; count-- {
+				out = append(out, b[i], b[i+1])
+			}
+			i += pair
+		}
+	}
+	return out
+}
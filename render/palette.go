@@ -0,0 +1,69 @@
+package render
+
+import (
+	"image/color"
+
+	"github.com/bengarrett/sauce/screen"
+)
+
+// colors is the number of colors in a text mode palette.
+const colors = 16
+
+// VGA returns the 16 color palette of a VGA text mode screen in ANSI order,
+// black, red, green, brown, blue, magenta, cyan and light gray,
+// followed by their high intensity variants.
+func VGA() color.Palette {
+	const lo, hi, mid = 0xaa, 0xff, 0x55
+	return color.Palette{
+		color.RGBA{0, 0, 0, hi},
+		color.RGBA{lo, 0, 0, hi},
+		color.RGBA{0, lo, 0, hi},
+		color.RGBA{lo, mid, 0, hi},
+		color.RGBA{0, 0, lo, hi},
+		color.RGBA{lo, 0, lo, hi},
+		color.RGBA{0, lo, lo, hi},
+		color.RGBA{lo, lo, lo, hi},
+		color.RGBA{mid, mid, mid, hi},
+		color.RGBA{hi, mid, mid, hi},
+		color.RGBA{mid, hi, mid, hi},
+		color.RGBA{hi, hi, mid, hi},
+		color.RGBA{mid, mid, hi, hi},
+		color.RGBA{hi, mid, hi, hi},
+		color.RGBA{mid, hi, hi, hi},
+		color.RGBA{hi, hi, hi, hi},
+	}
+}
+
+// rgba returns the color c using the 16 color palette pal,
+// with the higher palette indexes using the xterm 256 color palette.
+func rgba(pal color.Palette, c screen.Color) color.RGBA {
+	const opaque = 0xff
+	if c.IsRGB() {
+		r, g, b := c.RGB()
+		return color.RGBA{r, g, b, opaque}
+	}
+	i := c.Index()
+	if int(i) < colors {
+		r, g, b, _ := pal[i].RGBA()
+		return color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), opaque} //nolint:gosec
+	}
+	return xterm(i)
+}
+
+// xterm returns the color of the palette index i, from the 6x6x6 color cube
+// or the 24 step grayscale ramp of the xterm 256 color palette.
+func xterm(i uint8) color.RGBA {
+	const opaque, cube, gray = 0xff, 16, 232
+	if i >= gray {
+		v := 8 + (i-gray)*10
+		return color.RGBA{v, v, v, opaque}
+	}
+	level := func(n uint8) uint8 {
+		if n == 0 {
+			return 0
+		}
+		return 55 + n*40
+	}
+	n := i - cube
+	return color.RGBA{level(n / 36), level(n / 6 % 6), level(n % 6), opaque}
+}
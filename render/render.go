@@ -0,0 +1,165 @@
+// Package render draws text mode artwork to an image, UTF-8 terminal text or HTML.
+//
+// The character cells of a screen are drawn with the IBM PC code page 437
+// bitmap fonts and the 16 color VGA palette, following the SAUCE ANSiFlags
+// that describe how the artwork was meant to be displayed on a DOS machine.
+// The terminal and HTML output translate the CP437 characters to Unicode.
+//
+// See http://www.acid.org/info/sauce/sauce.htm#ANSiFlags
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/font"
+	"github.com/bengarrett/sauce/screen"
+)
+
+// MaxPixels is the maximum area of an image drawn by [Image], which is about 128 MiB of RGBA pixels.
+// The rows of a screen that do not fit within the area are not drawn.
+const MaxPixels = 1 << 25
+
+// Options are the display settings used to draw the artwork.
+type Options struct {
+	NonBlink bool          // non-blink mode uses the blink attribute for high intensity backgrounds, also known as iCE colors
+	NinePx   bool          // ninepx is the 9 pixel letter-spacing of a VGA text mode screen
+	Stretch  bool          // stretch the pixels to the 4:3 aspect ratio of a legacy CRT display
+	Font     string        // font name of the SAUCE TInfoS field, such as "IBM VGA" or "IBM VGA50"
+	Palette  color.Palette // palette of 16 colors in ANSI order, or nil to use the VGA palette
+	Glyphs   []byte        // glyphs are custom bitmap font data of 256 glyphs, which replaces the font name
+}
+
+// NewOptions returns the display settings described by the ANSiFlags and font name of the SAUCE record.
+func NewOptions(r *sauce.Record) Options {
+	if r == nil {
+		return Options{}
+	}
+	flags := r.Info.Flags
+	return Options{
+		NonBlink: flags.NonBlink(),
+		NinePx:   flags.LetterSpacing() == sauce.LS9px,
+		Stretch:  flags.AspectRatio() == sauce.ARStretch,
+		Font:     r.Info.Font,
+	}
+}
+
+// PNG draws the artwork b and encodes it as a PNG image to w.
+// The SAUCE record of b, when present, describes the type of data and the display settings.
+func PNG(w io.Writer, b []byte) error {
+	r := sauce.Decode(b)
+	s, opts, err := Load(b, &r)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(w, Image(s, opts)); err != nil {
+		return fmt.Errorf("png encode: %w", err)
+	}
+	return nil
+}
+
+// Image draws the character cells of the screen s to an image.
+// The image is sized to the rows written to the screen, which are clipped
+// to the rows that fit within [MaxPixels] once stretched.
+func Image(s *screen.Screen, opts Options) *image.RGBA {
+	f := opts.font()
+	pal := opts.palette()
+	cw := font.Width
+	if opts.NinePx {
+		cw++
+	}
+	rows := max(min(s.Height(), maxRows(s.Width()*cw, f.Height, opts)), 1)
+	img := image.NewRGBA(image.Rect(0, 0, s.Width()*cw, rows*f.Height))
+	for y := range rows {
+		for x := range s.Width() {
+			cell := s.Cell(x, y)
+			fg, bg := cell.Attr.Colors(opts.NonBlink)
+			glyph(img, f, cell.Char, x*cw, y*f.Height, cw, rgba(pal, fg), rgba(pal, bg))
+		}
+	}
+	if opts.Stretch {
+		return stretch(img, ratio(opts.NinePx))
+	}
+	return img
+}
+
+// maxRows returns the number of character rows of width pixels and height pixels
+// that can be drawn without the image exceeding MaxPixels.
+func maxRows(width, height int, opts Options) int {
+	if width < 1 || height < 1 {
+		return 0
+	}
+	area, limit := float64(width*height), float64(MaxPixels)
+	if opts.Stretch {
+		area *= ratio(opts.NinePx)
+		limit -= float64(width) // allow for the rounding of the stretched height
+	}
+	return int(limit / area)
+}
+
+// glyph draws the character c with its top left corner at pixel x0, y0.
+// A ninth column repeats the eighth column of the line drawing characters,
+// otherwise it is left as the background color.
+func glyph(img *image.RGBA, f *font.Font, c byte, x0, y0, cw int, fg, bg color.RGBA) {
+	const msb, last = 0x80, 0x01
+	for y, bits := range f.Glyph(c) {
+		for x := range cw {
+			on := false
+			switch {
+			case x < font.Width:
+				on = bits&(msb>>x) != 0
+			case font.LineDrawing(c):
+				on = bits&last != 0
+			}
+			if on {
+				img.SetRGBA(x0+x, y0+y, fg)
+				continue
+			}
+			img.SetRGBA(x0+x, y0+y, bg)
+		}
+	}
+}
+
+// ratio returns the vertical stretch of a VGA text mode screen that is displayed at 4:3,
+// where 720x400 pixels stretch to 720x540 and 640x400 pixels stretch to 640x480.
+func ratio(ninePx bool) float64 {
+	const nine, eight = 1.35, 1.2
+	if ninePx {
+		return nine
+	}
+	return eight
+}
+
+// stretch returns a copy of img that is vertically scaled by the factor,
+// using the nearest neighbour rows.
+func stretch(img *image.RGBA, factor float64) *image.RGBA {
+	b := img.Bounds()
+	h := int(math.Round(float64(b.Dy()) * factor))
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), h))
+	for y := range h {
+		src := y * b.Dy() / h
+		copy(dst.Pix[y*dst.Stride:(y+1)*dst.Stride], img.Pix[src*img.Stride:(src+1)*img.Stride])
+	}
+	return dst
+}
+
+func (opts Options) font() *font.Font {
+	if len(opts.Glyphs) > 0 {
+		if f, err := font.New("custom", opts.Glyphs); err == nil {
+			return f
+		}
+	}
+	return font.Lookup(opts.Font)
+}
+
+func (opts Options) palette() color.Palette {
+	if len(opts.Palette) >= colors {
+		return opts.Palette
+	}
+	return VGA()
+}
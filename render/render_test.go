@@ -0,0 +1,226 @@
+package render_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/render"
+	"github.com/bengarrett/sauce/screen"
+)
+
+func TestImage(t *testing.T) {
+	t.Parallel()
+	s := screen.Load([]byte("Hi\r\nthere"), 0)
+	tests := []struct {
+		name  string
+		opts  render.Options
+		wantW int
+		wantH int
+	}{
+		{"default", render.Options{}, 640, 32},
+		{"nine pixel", render.Options{NinePx: true}, 720, 32},
+		{"small font", render.Options{Font: "IBM VGA50"}, 640, 16},
+		{"stretch", render.Options{Stretch: true}, 640, 38},
+		{"nine stretch", render.Options{NinePx: true, Stretch: true}, 720, 43},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			b := render.Image(s, tt.opts).Bounds()
+			if b.Dx() != tt.wantW || b.Dy() != tt.wantH {
+				t.Errorf("Image() = %dx%d, want %dx%d", b.Dx(), b.Dy(), tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestImage_Colors(t *testing.T) {
+	t.Parallel()
+	red := color.RGBA{0xaa, 0, 0, 0xff}
+	brightRed := color.RGBA{0xff, 0x55, 0x55, 0xff}
+	// a space with a blinking red background, followed by a line drawing character
+	s := screen.Load([]byte("\x1b[5;41m \x1b[0;31m\xc4"), 0)
+	img := render.Image(s, render.Options{})
+	if got := img.RGBAAt(0, 0); got != red {
+		t.Errorf("blink mode pixel = %v, want %v", got, red)
+	}
+	img = render.Image(s, render.Options{NonBlink: true, NinePx: true})
+	if got := img.RGBAAt(0, 0); got != brightRed {
+		t.Errorf("non-blink mode pixel = %v, want %v", got, brightRed)
+	}
+	// the horizontal line continues into the ninth column
+	const row = 7
+	if got := img.RGBAAt(9+8, row); got != red {
+		t.Errorf("line drawing ninth column = %v, want %v", got, red)
+	}
+	if got := img.RGBAAt(8, row); got != brightRed {
+		t.Errorf("space ninth column = %v, want the background %v", got, brightRed)
+	}
+	s = screen.Load([]byte("\x1b[38;2;1;2;3m\xdb"), 0)
+	img = render.Image(s, render.Options{})
+	if got, want := img.RGBAAt(0, 0), (color.RGBA{1, 2, 3, 0xff}); got != want {
+		t.Errorf("truecolor pixel = %v, want %v", got, want)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	// binary text of 2 columns with a white on blue A
+	bin := []byte("A\x1fB\x07C\x07")
+	r := sauce.Record{}
+	r.Data.Type = 5
+	r.File.Type = 1
+	s, _, err := render.Load(bin, &r)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if s.Width() != 2 || s.Height() != 2 {
+		t.Errorf("Load() binary = %dx%d, want 2x2", s.Width(), s.Height())
+	}
+	cell := s.Cell(0, 0)
+	if cell.Char != 'A' || cell.Attr.FG != screen.White+8 || cell.Attr.BG != screen.Blue {
+		t.Errorf("Load() binary cell = %+v, want a bright white on blue A", cell)
+	}
+	r.Data.Type = 3
+	if _, _, err := render.Load(bin, &r); !errors.Is(err, render.ErrUnsupported) {
+		t.Errorf("Load() vector error = %v, want %v", err, render.ErrUnsupported)
+	}
+}
+
+func TestXBin(t *testing.T) {
+	t.Parallel()
+	// a 2x1 xbin using non-blink mode and compression, with a run of 2 red on black X characters
+	b := []byte("XBIN\x1a\x02\x00\x01\x00\x10\x0c\xc1X\x04")
+	s, opts, err := render.XBin(b)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !opts.NonBlink {
+		t.Error("XBin() non-blink = false, want true")
+	}
+	for x := range 2 {
+		if c := s.Cell(x, 0); c.Char != 'X' || c.Attr.FG != screen.Red {
+			t.Errorf("XBin() cell %d = %+v, want a red X", x, c)
+		}
+	}
+	if _, _, err := render.XBin([]byte("XBIN")); !errors.Is(err, render.ErrXBin) {
+		t.Errorf("XBin() error = %v, want %v", err, render.ErrXBin)
+	}
+	// a compressed 65535x65535 header must not reserve memory for every cell
+	if _, _, err := render.XBin([]byte("XBIN\x1a\xff\xff\xff\xff\x10\x04\xffX\x04")); !errors.Is(err, render.ErrXBin) {
+		t.Errorf("XBin() oversized error = %v, want %v", err, render.ErrXBin)
+	}
+	// a tall xbin with little data only expands the data it holds
+	s, _, err = render.XBin([]byte("XBIN\x1a\x50\x00\xff\xff\x10\x04\xc1X\x04"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st := s.Stats(); st.Columns != 2 || st.Lines != 1 {
+		t.Errorf("XBin() tall stats = %+v, want 2 columns and 1 line", st)
+	}
+}
+
+func TestPNG(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := render.PNG(&buf, []byte("\x1b[1;33mHello\x1b[0m")); err != nil {
+		t.Error(err)
+		return
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got := img.Bounds().Dx(); got != 640 {
+		t.Errorf("PNG() width = %d, want 640", got)
+	}
+}
+
+func TestLoad_PCBoard(t *testing.T) {
+	t.Parallel()
+	r := sauce.Record{}
+	r.Data.Type = 1
+	r.File.Type = 4
+	s, _, err := render.Load([]byte("@X1EHi"), &r)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if c := s.Cell(0, 0); c.Char != 'H' || c.Attr.FG != screen.Yellow || !c.Attr.Bold || c.Attr.BG != screen.Blue {
+		t.Errorf("Load() pcboard cell = %+v, want a yellow on blue H", c)
+	}
+	r.File.Type = 5
+	s, _, err = render.Load([]byte("\x19*\x03"), &r)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if st := s.Stats(); st.Columns != 3 {
+		t.Errorf("Load() avatar columns = %d, want 3", st.Columns)
+	}
+}
+
+func TestLoad_Width(t *testing.T) {
+	t.Parallel()
+	r := sauce.Record{}
+	r.Data.Type = 1
+	r.File.Type = 1
+	tests := []struct {
+		name  string
+		b     []byte
+		info1 uint16
+		want  int
+	}{
+		{"default", []byte("hi"), 0, screen.Columns},
+		{"narrow", []byte("hi"), 40, 40},
+		{"wide", bytes.Repeat([]byte("x"), 200), 160, 160},
+		{"wider than the text", bytes.Repeat([]byte("x"), 100), 0xffff, 100},
+		{"short text", []byte("hi"), screen.MaxColumns, screen.Columns},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := r
+			r.Info.Info1.Value = tt.info1
+			s, _, err := render.Load(tt.b, &r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.Width() != tt.want {
+				t.Errorf("Load() width = %d, want %d", s.Width(), tt.want)
+			}
+		})
+	}
+}
+
+func ExampleImage() {
+	s := screen.Load([]byte("\x1b[1;33mHello\x1b[0m"), 0)
+	img := render.Image(s, render.Options{NinePx: true})
+	fmt.Println(img.Bounds().Dx(), "x", img.Bounds().Dy())
+	// Output: 720 x 16
+}
+
+func TestImage_MaxPixels(t *testing.T) {
+	t.Parallel()
+	s, opts, err := render.Load([]byte("\x1b[65535BX"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Height(); got != screen.MaxLines {
+		t.Fatalf("Height() = %d, want %d", got, screen.MaxLines)
+	}
+	for _, o := range []render.Options{opts, {NinePx: true, Stretch: true}, {Font: "IBM VGA50"}} {
+		b := render.Image(s, o).Bounds()
+		if b.Dx() == 0 || b.Dy() == 0 || b.Dx()*b.Dy() > render.MaxPixels {
+			t.Errorf("Image(%+v) = %dx%d, want no more than %d pixels", o, b.Dx(), b.Dy(), render.MaxPixels)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/humanize"
+	"github.com/bengarrett/sauce/internal/cp437"
+	"github.com/bengarrett/sauce/screen"
+)
+
+// Colors are the colors supported by a terminal.
+type Colors uint8
+
+const (
+	Colors16  Colors = iota // the 16 ANSI colors and their high intensity variants
+	TrueColor               // 24-bit RGB color values
+)
+
+// UTF8 plays back the artwork b and writes it to w as UTF-8 text for a modern terminal,
+// followed by a summary of the SAUCE record of b, when present.
+func UTF8(w io.Writer, b []byte, c Colors) error {
+	r := sauce.Decode(b)
+	s, opts, err := Load(b, &r)
+	if err != nil {
+		return err
+	}
+	if err := Terminal(w, s, opts, c); err != nil {
+		return err
+	}
+	if !r.Valid() {
+		return nil
+	}
+	if _, err := io.WriteString(w, Footer(&r)); err != nil {
+		return fmt.Errorf("write footer: %w", err)
+	}
+	return nil
+}
+
+// Terminal writes the character cells of the screen s to w as UTF-8 text
+// with SGR color sequences.
+//
+// The CP437 characters are translated to Unicode and the cursor movement of the
+// original text is replaced by explicit rows of text, each wrapped at the screen width.
+// Blinking text displays a high intensity background in non-blink mode,
+// while in blink mode the blink attribute is ignored.
+// The trailing blank cells of each row are not written.
+func Terminal(w io.Writer, s *screen.Screen, opts Options, c Colors) error {
+	pal := opts.palette()
+	var buf bytes.Buffer
+	for y := range s.Height() {
+		buf.Reset()
+		row := s.Row(y)
+		end := len(row)
+		for end > 0 && blank(row[end-1]) {
+			end--
+		}
+		prev := ""
+		for _, cell := range row[:end] {
+			fg, bg := cell.Attr.Colors(opts.NonBlink)
+			if sgr := sgrColors(pal, fg, bg, c); sgr != prev {
+				buf.WriteString(sgr)
+				prev = sgr
+			}
+			buf.WriteRune(cp437.Rune(cell.Char))
+		}
+		if prev != "" {
+			buf.WriteString("\x1b[0m")
+		}
+		buf.WriteByte('\n')
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return fmt.Errorf("terminal write: %w", err)
+		}
+	}
+	return nil
+}
+
+// Footer returns a formatted summary of the SAUCE record r,
+// with the CP437 text translated to Unicode.
+func Footer(r *sauce.Record) string {
+	if r == nil {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat("─", 8) + " SAUCE " + strings.Repeat("─", 65) + "\n")
+	field := func(name, value string) {
+		if strings.TrimSpace(value) == "" {
+			return
+		}
+		fmt.Fprintf(&sb, "%-9s%s\n", name+":", value)
+	}
+	field("Title", text(r.Title))
+	field("Author", text(r.Author))
+	field("Group", text(r.Group))
+	if !r.Date.Time.IsZero() {
+		field("Date", humanize.DMY.Format(r.Date.Time))
+	}
+	field("Size", r.FileSize.Decimal)
+	field("Type", strings.TrimSpace(r.Data.Name+", "+r.File.Name))
+	for _, info := range []struct {
+		name  string
+		value uint16
+	}{
+		{r.Info.Info1.Info, r.Info.Info1.Value},
+		{r.Info.Info2.Info, r.Info.Info2.Value},
+		{r.Info.Info3.Info, r.Info.Info3.Value},
+	} {
+		if info.name != "" && info.value > 0 {
+			field(strings.ToUpper(info.name[:1])+info.name[1:], strconv.Itoa(int(info.value)))
+		}
+	}
+	field("Flags", r.Info.Flags.String())
+	field("Font", r.Info.Font)
+	for i, line := range r.Comnt.Comment {
+		name := ""
+		if i == 0 {
+			name = "Comments"
+		}
+		fmt.Fprintf(&sb, "%-9s%s\n", name, strings.TrimRight(text(line), " "))
+	}
+	return sb.String()
+}
+
+// blank reports whether the cell is a space, or an empty cell, on the default background.
+func blank(c screen.Cell) bool {
+	const nul = 0x00
+	return (c.Char == ' ' || c.Char == nul) && c.Attr.BG == screen.Black && !c.Attr.Reverse
+}
+
+// sgrColors returns the SGR sequence of the foreground and background colors.
+func sgrColors(pal color.Palette, fg, bg screen.Color, c Colors) string {
+	if c == TrueColor {
+		f, b := rgba(pal, fg), rgba(pal, bg)
+		return fmt.Sprintf("\x1b[0;38;2;%d;%d;%d;48;2;%d;%d;%dm",
+			f.R, f.G, f.B, b.R, b.G, b.B)
+	}
+	const fgBase, bgBase, brightFG, brightBG = 30, 40, 90, 100
+	param := func(c screen.Color, base, brightBase int) int {
+		i := nearest(pal, c)
+		if i >= colors/2 {
+			return brightBase + i - colors/2
+		}
+		return base + i
+	}
+	return fmt.Sprintf("\x1b[0;%d;%dm",
+		param(fg, fgBase, brightFG), param(bg, bgBase, brightBG))
+}
+
+// nearest returns the index of the 16 color palette that is closest to the color c.
+func nearest(pal color.Palette, c screen.Color) int {
+	if !c.IsRGB() && int(c.Index()) < colors {
+		return int(c.Index())
+	}
+	return pal[:colors].Index(rgba(pal, c))
+}
@@ -0,0 +1,98 @@
+package render_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/render"
+	"github.com/bengarrett/sauce/screen"
+)
+
+func TestTerminal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		b    string
+		opts render.Options
+		c    render.Colors
+		want string
+	}{
+		{"plain", "Hi   \r\n\xdb\xb0", render.Options{}, render.Colors16,
+			"\x1b[0;37;40mHi\x1b[0m\n\x1b[0;37;40m█░\x1b[0m\n"},
+		{"cursor", "A\x1b[3CB", render.Options{}, render.Colors16,
+			"\x1b[0;37;40mA   B\x1b[0m\n"},
+		{"wrap", "ABCDEF", render.Options{}, render.Colors16,
+			"\x1b[0;37;40mABCD\x1b[0m\n\x1b[0;37;40mEF\x1b[0m\n"},
+		{"blink", "\x1b[5;1;31;44mX", render.Options{}, render.Colors16,
+			"\x1b[0;91;44mX\x1b[0m\n"},
+		{"ice", "\x1b[5;1;31;44mX", render.Options{NonBlink: true}, render.Colors16,
+			"\x1b[0;91;104mX\x1b[0m\n"},
+		{"truecolor", "\x1b[1;33mX", render.Options{}, render.TrueColor,
+			"\x1b[0;38;2;255;255;85;48;2;0;0;0mX\x1b[0m\n"},
+		{"nearest", "\x1b[38;2;250;250;250mX", render.Options{}, render.Colors16,
+			"\x1b[0;97;40mX\x1b[0m\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			width := 0
+			if tt.name == "wrap" {
+				width = 4
+			}
+			var buf bytes.Buffer
+			s := screen.Load([]byte(tt.b), width)
+			if err := render.Terminal(&buf, s, tt.opts, tt.c); err != nil {
+				t.Error(err)
+				return
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Terminal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUTF8(t *testing.T) {
+	t.Parallel()
+	b, err := static.ReadFile("static/sauce.txt")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var buf bytes.Buffer
+	if err := render.UTF8(&buf, b, render.Colors16); err != nil {
+		t.Error(err)
+		return
+	}
+	got := buf.String()
+	for _, want := range []string{"─ SAUCE ─", "Title:   Sauce title", "Date:    26 Nov 2016"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("UTF8() is missing %q", want)
+		}
+	}
+}
+
+func TestFooter(t *testing.T) {
+	t.Parallel()
+	if got := render.Footer(nil); got != "" {
+		t.Errorf("Footer(nil) = %q, want an empty string", got)
+	}
+	r := sauce.Record{Title: "Hello", Author: "  ", Group: "\x84CiD"}
+	r.Comnt.Comment = []string{"first line", "second \x1b[2J"}
+	got := render.Footer(&r)
+	if !strings.Contains(got, "Title:   Hello\n") {
+		t.Errorf("Footer() = %q, want a title", got)
+	}
+	if strings.Contains(got, "Author") {
+		t.Errorf("Footer() = %q, want no empty author", got)
+	}
+	if !strings.Contains(got, "Group:   äCiD\n") {
+		t.Errorf("Footer() = %q, want a UTF-8 group", got)
+	}
+	// the escape control code is displayed as its CP437 glyph
+	if !strings.Contains(got, "Comments first line\n         second ←[2J\n") {
+		t.Errorf("Footer() = %q, want the comments", got)
+	}
+}
@@ -0,0 +1,92 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/bengarrett/sauce/screen"
+)
+
+// ErrTundra is returned when the data is not TundraDraw color text.
+var ErrTundra = errors.New("invalid tundradraw data")
+
+// TundraID is the header of a TundraDraw file, a version byte of 24 followed by "TUNDRA24".
+const TundraID = "\x18TUNDRA24"
+
+// IsTundra reports whether b begins with the TundraDraw header.
+func IsTundra(b []byte) bool {
+	return bytes.HasPrefix(b, []byte(TundraID))
+}
+
+// Tundra returns the screen of the TundraDraw color text b.
+//
+// TundraDraw is an 80 column format of CP437 characters and inline commands,
+// which use 24-bit RGB colors rather than a 16 color palette.
+// A command byte of 1 moves the cursor to a row and column within the 80 columns, while 2, 4 and 6
+// set the foreground, the background or both colors of the character that follows.
+func Tundra(b []byte) (*screen.Screen, error) {
+	const (
+		position = 1
+		fg       = 2
+		bg       = 4
+		both     = 6
+		rgb      = 4 // rgb is the size of a color, an unused byte followed by the red, green and blue values
+	)
+	if !IsTundra(b) {
+		return nil, fmt.Errorf("%w: header", ErrTundra)
+	}
+	s := screen.New(screen.Columns)
+	attr := screen.Default()
+	x, y := 0, 0
+	// color returns the 24-bit color stored at position i of b
+	color := func(i int) screen.Color {
+		return screen.RGB(b[i+1], b[i+2], b[i+3])
+	}
+	for i := len(TundraID); i < len(b); i++ {
+		c := b[i]
+		switch c {
+		case position:
+			const size = 8
+			if i+size >= len(b) {
+				return s, fmt.Errorf("%w: position at offset %d", ErrTundra, i)
+			}
+			y = int(binary.BigEndian.Uint32(b[i+1 : i+5]))
+			// a column beyond the screen is moved to the last column
+			x = min(int(binary.BigEndian.Uint32(b[i+5:i+9])), screen.Columns-1)
+			i += size
+			continue
+		case fg, bg:
+			const size = 1 + rgb
+			if i+size >= len(b) {
+				return s, fmt.Errorf("%w: color at offset %d", ErrTundra, i)
+			}
+			if c == fg {
+				attr.FG = color(i + 2)
+			} else {
+				attr.BG = color(i + 2)
+			}
+			c = b[i+1]
+			i += size
+		case both:
+			const size = 1 + rgb + rgb
+			if i+size >= len(b) {
+				return s, fmt.Errorf("%w: colors at offset %d", ErrTundra, i)
+			}
+			attr.FG, attr.BG = color(i+2), color(i+2+rgb)
+			c = b[i+1]
+			i += size
+		}
+		if y >= screen.MaxLines {
+			return s, fmt.Errorf("%w: too many lines", ErrTundra)
+		}
+		s.Put(x, y, screen.Cell{Char: c, Attr: attr})
+		x++
+		if x == screen.Columns {
+			x = 0
+			y++
+		}
+	}
+	return s, nil
+}
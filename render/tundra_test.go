@@ -0,0 +1,79 @@
+package render_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/render"
+	"github.com/bengarrett/sauce/screen"
+)
+
+func TestTundra(t *testing.T) {
+	t.Parallel()
+	b := []byte(render.TundraID +
+		"A" +
+		"\x02B\x00\xff\x80\x00" + // orange foreground B
+		"\x04C\x00\x00\x00\x40" + // navy background C
+		"\x01\x00\x00\x00\x02\x00\x00\x00\x05" + // move to row 2, column 5
+		"\x06D\x00\x01\x02\x03\x00\x04\x05\x06")
+	s, err := render.Tundra(b)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	tests := []struct {
+		x, y   int
+		char   byte
+		fg, bg screen.Color
+	}{
+		{0, 0, 'A', screen.White, screen.Black},
+		{1, 0, 'B', screen.RGB(0xff, 0x80, 0), screen.Black},
+		{2, 0, 'C', screen.RGB(0xff, 0x80, 0), screen.RGB(0, 0, 0x40)},
+		{5, 2, 'D', screen.RGB(1, 2, 3), screen.RGB(4, 5, 6)},
+	}
+	for _, tt := range tests {
+		c := s.Cell(tt.x, tt.y)
+		if c.Char != tt.char || c.Attr.FG != tt.fg || c.Attr.BG != tt.bg {
+			t.Errorf("Tundra() cell %d,%d = %+v, want %q %v on %v", tt.x, tt.y, c, tt.char, tt.fg, tt.bg)
+		}
+	}
+	if st := s.Stats(); st.Lines != 3 || st.Columns != 6 {
+		t.Errorf("Tundra() stats = %+v, want 6 columns and 3 lines", st)
+	}
+	// a column beyond the screen is clamped to the last column, and the text that follows wraps
+	s, err = render.Tundra([]byte(render.TundraID + "\x01\x00\x00\x00\x00\x00\x00\x00\xc8EF"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := s.Cell(screen.Columns-1, 0); c.Char != 'E' {
+		t.Errorf("Tundra() clamped cell = %q, want E", c.Char)
+	}
+	if c := s.Cell(0, 1); c.Char != 'F' {
+		t.Errorf("Tundra() wrapped cell = %q, want F", c.Char)
+	}
+	if _, err := render.Tundra([]byte("TUNDRA24")); !errors.Is(err, render.ErrTundra) {
+		t.Errorf("Tundra() error = %v, want %v", err, render.ErrTundra)
+	}
+	if _, err := render.Tundra([]byte(render.TundraID + "\x02B\x00")); !errors.Is(err, render.ErrTundra) {
+		t.Errorf("Tundra() truncated error = %v, want %v", err, render.ErrTundra)
+	}
+}
+
+func TestLoad_Tundra(t *testing.T) {
+	t.Parallel()
+	r := sauce.Record{}
+	r.Data.Type = 1
+	r.File.Type = 8
+	if _, _, err := render.Load([]byte("ANSI"), &r); !errors.Is(err, render.ErrTundra) {
+		t.Errorf("Load() error = %v, want %v", err, render.ErrTundra)
+	}
+	s, _, err := render.Load([]byte(render.TundraID+"Hi"), &r)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got := s.Cell(1, 0).Char; got != 'i' {
+		t.Errorf("Load() cell = %q, want %q", got, 'i')
+	}
+}
@@ -0,0 +1,432 @@
+package rip
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"slices"
+
+	"github.com/bengarrett/sauce/internal/font"
+)
+
+// EGA returns the color of the EGA 64 color value v,
+// where the low 3 bits are the blue, green and red primary intensities
+// and the next 3 bits are the blue, green and red secondary intensities.
+func EGA(v uint8) color.RGBA {
+	const primary, secondary, opaque = 0xaa, 0x55, 0xff
+	level := func(p, s uint8) uint8 {
+		return (v>>p)&1*primary + (v>>s)&1*secondary
+	}
+	return color.RGBA{level(2, 5), level(1, 4), level(0, 3), opaque}
+}
+
+// Palette returns the default 16 color EGA palette.
+func Palette() color.Palette {
+	values := [...]uint8{0, 1, 2, 3, 4, 5, 20, 7, 56, 57, 58, 59, 60, 61, 62, 63}
+	pal := make(color.Palette, len(values))
+	for i, v := range values {
+		pal[i] = EGA(v)
+	}
+	return pal
+}
+
+// Image parses and draws the RIPscrip stream b to an image of 640x350 pixels.
+// The commands before any parse error are drawn.
+func Image(b []byte) (*image.Paletted, error) {
+	cmds, err := Parse(b)
+	return Draw(cmds), err
+}
+
+// Draw returns an image of 640x350 pixels that is drawn by the RIPscrip commands.
+//
+// The drawing commands, such as lines, rectangles, bars, circles, ovals, arcs,
+// pie slices, polygons, Bezier curves, flood fills and text, are supported,
+// while the level 1 and higher commands, such as mouse regions and buttons, are ignored.
+// Line patterns and fill patterns are drawn as solid, and text uses the 8x8 bitmap font
+// that is scaled by the font size.
+func Draw(cmds []Command) *image.Paletted {
+	d := newDrawing()
+	for _, c := range cmds {
+		if c.Level > 0 {
+			continue
+		}
+		d.command(c)
+	}
+	return d.img
+}
+
+type drawing struct {
+	img      *image.Paletted
+	view     image.Rectangle // view is the graphics viewport, which is the origin and clip of drawing
+	x, y     int             // x and y are the current drawing position
+	color    uint8           // color is the drawing color
+	fill     uint8           // fill is the fill color
+	xor      bool            // xor is the exclusive or write mode
+	thick    int             // thick is the line thickness in pixels
+	fontSize int             // fontsize scales the text
+	glyphs   *font.Font
+}
+
+func newDrawing() *drawing {
+	rect := image.Rect(0, 0, Width, Height)
+	return &drawing{
+		img:      image.NewPaletted(rect, Palette()),
+		view:     rect,
+		color:    Colors - 1,
+		fill:     Colors - 1,
+		thick:    1,
+		fontSize: 1,
+		glyphs:   font.Small(),
+	}
+}
+
+func (d *drawing) command(c Command) {
+	a := newArgs(c.Args)
+	const two = 2
+	switch c.Name {
+	case '*':
+		rect := image.Rect(0, 0, Width, Height)
+		d.img = image.NewPaletted(rect, Palette())
+		d.view = rect
+	case 'E':
+		d.clear()
+	case 'v':
+		x0, y0, x1, y1 := a.num(two), a.num(two), a.num(two), a.num(two)
+		d.view = image.Rect(x0, y0, x1+1, y1+1).Intersect(d.img.Bounds())
+	case 'c':
+		d.color = uint8(a.num(two) % Colors) //nolint:gosec
+	case 'Q':
+		for i := range Colors {
+			d.img.Palette[i] = EGA(uint8(a.num(two) % 64)) //nolint:gosec
+		}
+	case 'a':
+		i, v := a.num(two), a.num(two)
+		d.img.Palette[i%Colors] = EGA(uint8(v % 64)) //nolint:gosec
+	case 'W':
+		d.xor = a.num(two) == 1
+	case 'm':
+		d.x, d.y = a.num(two), a.num(two)
+	case 'T':
+		d.text(a.text())
+	case '@':
+		d.x, d.y = a.num(two), a.num(two)
+		d.text(a.text())
+	case 'Y':
+		// the font size is a multiplier of the 8x8 font from 1 to 10
+		const maxSize = 10
+		_, _, size := a.num(two), a.num(two), a.num(two)
+		d.fontSize = min(max(1, size), maxSize)
+	case 'X':
+		d.plot(a.num(two), a.num(two), d.color)
+	case '=':
+		const thick = 3
+		_, _, t := a.num(two), a.num(4), a.num(two)
+		d.thick = 1
+		if t == thick {
+			d.thick = thick
+		}
+	case 'S':
+		_, fill := a.num(two), a.num(two)
+		d.fill = uint8(fill % Colors) //nolint:gosec
+	case 's':
+		for range 8 {
+			a.num(two)
+		}
+		d.fill = uint8(a.num(two) % Colors) //nolint:gosec
+	case 'F':
+		x, y, border := a.num(two), a.num(two), a.num(two)
+		d.flood(x, y, uint8(border%Colors)) //nolint:gosec
+	default:
+		d.shape(c.Name, a)
+	}
+}
+
+// shape draws the lines, rectangles, ellipses, polygons and curves.
+func (d *drawing) shape(name byte, a *args) {
+	const two = 2
+	switch name {
+	case 'L':
+		d.line(a.num(two), a.num(two), a.num(two), a.num(two))
+	case 'R':
+		x0, y0, x1, y1 := a.num(two), a.num(two), a.num(two), a.num(two)
+		d.polygon([]image.Point{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}}, true)
+	case 'B':
+		x0, y0, x1, y1 := a.num(two), a.num(two), a.num(two), a.num(two)
+		d.bar(x0, y0, x1, y1)
+	case 'C':
+		x, y, r := a.num(two), a.num(two), a.num(two)
+		d.arc(x, y, 0, 360, r, aspect(r))
+	case 'O', 'V':
+		x, y, start, end, rx, ry := a.num(two), a.num(two), a.num(two), a.num(two), a.num(two), a.num(two)
+		d.arc(x, y, start, end, rx, ry)
+	case 'o':
+		x, y, rx, ry := a.num(two), a.num(two), a.num(two), a.num(two)
+		d.oval(x, y, rx, ry)
+	case 'A':
+		x, y, start, end, r := a.num(two), a.num(two), a.num(two), a.num(two), a.num(two)
+		d.arc(x, y, start, end, r, aspect(r))
+	case 'I':
+		x, y, start, end, r := a.num(two), a.num(two), a.num(two), a.num(two), a.num(two)
+		d.pie(x, y, start, end, r, aspect(r))
+	case 'i':
+		x, y, start, end, rx, ry := a.num(two), a.num(two), a.num(two), a.num(two), a.num(two), a.num(two)
+		d.pie(x, y, start, end, rx, ry)
+	case 'Z':
+		pts := make([]image.Point, 4)
+		for i := range pts {
+			pts[i] = image.Point{a.num(two), a.num(two)}
+		}
+		d.bezier(pts, a.num(two))
+	case 'P', 'p', 'l':
+		pts := make([]image.Point, a.num(two))
+		for i := range pts {
+			pts[i] = image.Point{a.num(two), a.num(two)}
+		}
+		if name == 'p' {
+			d.fillPolygon(pts)
+		}
+		d.polygon(pts, name != 'l')
+	}
+}
+
+// aspect returns the vertical radius of a circle with the radius r,
+// which is corrected for the pixels of an EGA screen displayed at 4:3.
+func aspect(r int) int {
+	const num, den = 35, 48
+	return r * num / den
+}
+
+// set writes the color index to the pixel at x, y of the image, clipped to the viewport.
+func (d *drawing) set(x, y int, c uint8) {
+	if !(image.Point{x, y}).In(d.view) {
+		return
+	}
+	if d.xor {
+		c ^= d.img.ColorIndexAt(x, y)
+	}
+	d.img.SetColorIndex(x, y, c)
+}
+
+// plot writes the color index to the pixel at x, y relative to the viewport.
+func (d *drawing) plot(x, y int, c uint8) {
+	d.set(d.view.Min.X+x, d.view.Min.Y+y, c)
+}
+
+// brush plots a pixel of the drawing color using the line thickness.
+func (d *drawing) brush(x, y int) {
+	if d.thick <= 1 {
+		d.plot(x, y, d.color)
+		return
+	}
+	r := d.thick / 2
+	for dy := -r; dy <= r; dy++ {
+		for dx := -r; dx <= r; dx++ {
+			d.plot(x+dx, y+dy, d.color)
+		}
+	}
+}
+
+func (d *drawing) clear() {
+	for y := d.view.Min.Y; y < d.view.Max.Y; y++ {
+		for x := d.view.Min.X; x < d.view.Max.X; x++ {
+			d.img.SetColorIndex(x, y, 0)
+		}
+	}
+}
+
+// line draws a line using Bresenham's algorithm.
+func (d *drawing) line(x0, y0, x1, y1 int) {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := sign(x1-x0), sign(y1-y0)
+	e := dx + dy
+	for {
+		d.brush(x0, y0)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+// polygon draws lines between the points, which is closed when close is true.
+func (d *drawing) polygon(pts []image.Point, closed bool) {
+	for i := 1; i < len(pts); i++ {
+		d.line(pts[i-1].X, pts[i-1].Y, pts[i].X, pts[i].Y)
+	}
+	if closed && len(pts) > 2 {
+		last := pts[len(pts)-1]
+		d.line(last.X, last.Y, pts[0].X, pts[0].Y)
+	}
+}
+
+// bar fills a rectangle using the fill color, without a border.
+func (d *drawing) bar(x0, y0, x1, y1 int) {
+	for y := min(y0, y1); y <= max(y0, y1); y++ {
+		for x := min(x0, x1); x <= max(x0, x1); x++ {
+			d.plot(x, y, d.fill)
+		}
+	}
+}
+
+// point returns the point of the ellipse at the angle in degrees,
+// which is counter-clockwise from the 3 o'clock position.
+func point(x, y, rx, ry int, deg float64) image.Point {
+	rad := deg * math.Pi / 180
+	return image.Point{
+		X: x + int(math.Round(float64(rx)*math.Cos(rad))),
+		Y: y - int(math.Round(float64(ry)*math.Sin(rad))),
+	}
+}
+
+// arc draws an elliptical arc between the start and end angles in degrees.
+func (d *drawing) arc(x, y, start, end, rx, ry int) {
+	if end < start {
+		end += 360
+	}
+	steps := max(8, int(float64(end-start)*math.Pi/180*float64(max(rx, ry))))
+	prev := point(x, y, rx, ry, float64(start))
+	for i := 1; i <= steps; i++ {
+		p := point(x, y, rx, ry, float64(start)+float64(end-start)*float64(i)/float64(steps))
+		d.line(prev.X, prev.Y, p.X, p.Y)
+		prev = p
+	}
+}
+
+// oval draws a filled ellipse using the fill color, with a border of the drawing color.
+func (d *drawing) oval(x, y, rx, ry int) {
+	for dy := -ry; dy <= ry; dy++ {
+		w := 0
+		if ry > 0 {
+			w = int(float64(rx) * math.Sqrt(1-float64(dy*dy)/float64(ry*ry)))
+		}
+		for dx := -w; dx <= w; dx++ {
+			d.plot(x+dx, y+dy, d.fill)
+		}
+	}
+	d.arc(x, y, 0, 360, rx, ry)
+}
+
+// pie draws a pie slice with a border of the drawing color, that is filled using the fill color.
+func (d *drawing) pie(x, y, start, end, rx, ry int) {
+	d.arc(x, y, start, end, rx, ry)
+	p0, p1 := point(x, y, rx, ry, float64(start)), point(x, y, rx, ry, float64(end))
+	d.line(x, y, p0.X, p0.Y)
+	d.line(x, y, p1.X, p1.Y)
+	if end < start {
+		end += 360
+	}
+	mid := point(x, y, rx/2, ry/2, float64(start+end)/2)
+	d.flood(mid.X, mid.Y, d.color)
+}
+
+// bezier draws a cubic Bezier curve of the 4 control points, using the number of segments.
+func (d *drawing) bezier(pts []image.Point, segments int) {
+	segments = max(1, segments)
+	prev := pts[0]
+	for i := 1; i <= segments; i++ {
+		t := float64(i) / float64(segments)
+		u := 1 - t
+		b0, b1, b2, b3 := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+		p := image.Point{
+			X: int(math.Round(b0*float64(pts[0].X) + b1*float64(pts[1].X) + b2*float64(pts[2].X) + b3*float64(pts[3].X))),
+			Y: int(math.Round(b0*float64(pts[0].Y) + b1*float64(pts[1].Y) + b2*float64(pts[2].Y) + b3*float64(pts[3].Y))),
+		}
+		d.line(prev.X, prev.Y, p.X, p.Y)
+		prev = p
+	}
+}
+
+// fillPolygon fills the polygon using the fill color and the even-odd rule.
+func (d *drawing) fillPolygon(pts []image.Point) {
+	if len(pts) < 3 {
+		return
+	}
+	minY, maxY := pts[0].Y, pts[0].Y
+	for _, p := range pts {
+		minY, maxY = min(minY, p.Y), max(maxY, p.Y)
+	}
+	for y := minY; y <= maxY; y++ {
+		xs := []int{}
+		for i := range pts {
+			a, b := pts[i], pts[(i+1)%len(pts)]
+			if (a.Y <= y && b.Y > y) || (b.Y <= y && a.Y > y) {
+				xs = append(xs, a.X+(y-a.Y)*(b.X-a.X)/(b.Y-a.Y))
+			}
+		}
+		slices.Sort(xs)
+		for i := 0; i+1 < len(xs); i += 2 {
+			for x := xs[i]; x <= xs[i+1]; x++ {
+				d.plot(x, y, d.fill)
+			}
+		}
+	}
+}
+
+// flood fills the area around x, y that is bounded by the border color, using the fill color.
+func (d *drawing) flood(x, y int, border uint8) {
+	start := image.Point{d.view.Min.X + x, d.view.Min.Y + y}
+	if !start.In(d.view) {
+		return
+	}
+	seen := make(map[image.Point]bool)
+	stack := []image.Point{start}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !p.In(d.view) || seen[p] || d.img.ColorIndexAt(p.X, p.Y) == border {
+			continue
+		}
+		seen[p] = true
+		d.img.SetColorIndex(p.X, p.Y, d.fill)
+		stack = append(stack,
+			image.Point{p.X + 1, p.Y}, image.Point{p.X - 1, p.Y},
+			image.Point{p.X, p.Y + 1}, image.Point{p.X, p.Y - 1})
+	}
+}
+
+// text draws the CP437 text at the drawing position using the drawing color,
+// and moves the drawing position to the end of the text.
+func (d *drawing) text(s string) {
+	const msb = 0x80
+	f, scale := d.glyphs, d.fontSize
+	for _, c := range []byte(s) {
+		for gy, bits := range f.Glyph(c) {
+			for gx := range font.Width {
+				if bits&(msb>>gx) == 0 {
+					continue
+				}
+				for sy := range scale {
+					for sx := range scale {
+						d.plot(d.x+gx*scale+sx, d.y+gy*scale+sy, d.color)
+					}
+				}
+			}
+		}
+		d.x += font.Width * scale
+	}
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+func sign(i int) int {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	}
+	return 0
+}
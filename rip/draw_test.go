@@ -0,0 +1,105 @@
+package rip_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/bengarrett/sauce/rip"
+)
+
+func TestEGA(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		v    uint8
+		want color.RGBA
+	}{
+		{0, color.RGBA{0, 0, 0, 0xff}},
+		{1, color.RGBA{0, 0, 0xaa, 0xff}},
+		{20, color.RGBA{0xaa, 0x55, 0, 0xff}},
+		{63, color.RGBA{0xff, 0xff, 0xff, 0xff}},
+	}
+	for _, tt := range tests {
+		if got := rip.EGA(tt.v); got != tt.want {
+			t.Errorf("EGA(%d) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestImage(t *testing.T) {
+	t.Parallel()
+	// MegaNums are base-36, so 0A is 10, 14 is 40, 18 is 44 and 1E is 50
+	// a yellow line, a red bar, a blue rectangle that is flood filled with green and white text
+	img, err := rip.Image([]byte("!|c0E|L00000A00|S010C|B0A0A0F0F|c01|R14141E1E|S0102|F181801|c0F|@3232A"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if b := img.Bounds(); b.Dx() != rip.Width || b.Dy() != rip.Height {
+		t.Errorf("Image() = %v, want %dx%d", b, rip.Width, rip.Height)
+	}
+	tests := []struct {
+		name string
+		x, y int
+		want uint8
+	}{
+		{"line", 5, 0, 14},
+		{"beyond line", 11, 0, 0},
+		{"bar", 12, 12, 12},
+		{"rectangle", 40, 45, 1},
+		{"flood fill", 45, 45, 2},
+		{"outside", 60, 60, 0},
+		{"text", 110 + 2, 110 + 1, 15},
+	}
+	for _, tt := range tests {
+		if got := img.ColorIndexAt(tt.x, tt.y); got != tt.want {
+			t.Errorf("Image() %s pixel %d,%d = %d, want %d", tt.name, tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestImage_Viewport(t *testing.T) {
+	t.Parallel()
+	img, err := rip.Image([]byte("!|v0A0A1414|c0F|X0000|X1414"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got := img.ColorIndexAt(10, 10); got != 15 {
+		t.Errorf("Image() viewport origin = %d, want 15", got)
+	}
+	if got := img.ColorIndexAt(40+10, 40+10); got != 0 {
+		t.Errorf("Image() clipped pixel = %d, want 0", got)
+	}
+}
+
+func TestImage_FontSize(t *testing.T) {
+	t.Parallel()
+	// a font size of ZZ, which is 1295, is drawn at the largest size of 10
+	img, err := rip.Image([]byte("!|Y0000ZZ00|c0F|@0000A"))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// the top row of the letter A is lit from the third to fifth columns of the glyph
+	if got := img.ColorIndexAt(2*10+5, 5); got != 15 {
+		t.Errorf("Image() font size pixel = %d, want 15", got)
+	}
+	if got := img.ColorIndexAt(6*10+5, 5); got != 0 {
+		t.Errorf("Image() beyond font size pixel = %d, want 0", got)
+	}
+}
+
+func TestImage_Shapes(t *testing.T) {
+	t.Parallel()
+	const shapes = "!|c0C|C32320A|O3232005A0A05|o64640A05|A6464005A0A|I6464005A0A" +
+		"|i6464005A0A0A|Z00000A0A14001E0A05|p0300000A000A0A|l0300000A000A0A|W01|=00000003|L00009Q9Q"
+	img, err := rip.Image([]byte(shapes))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	// the circle of radius 10 at 110,110
+	if got := img.ColorIndexAt(120, 110); got != 12 {
+		t.Errorf("Image() circle pixel = %d, want 12", got)
+	}
+}
@@ -0,0 +1,269 @@
+// Package rip parses, validates and draws RIPscrip vector graphics.
+//
+// RIPscrip, the Remote Imaging Protocol script language, is a text stream of
+// graphic commands used by BBS terminals to draw on an EGA 640x350 pixel,
+// 16 color screen. Each line of commands begins with an exclamation mark and
+// every command begins with a vertical bar, a level number, the command
+// character and its arguments, which are mostly 2 digit base-36 MegaNums.
+//
+// See http://www.acid.org/info/sauce/sauce.htm#FileType
+package rip
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+// The fixed type information of a RIPscrip SAUCE record.
+const (
+	Width  = 640 // width of the screen in pixels, the TInfo1 value
+	Height = 350 // height of the screen in pixels, the TInfo2 value
+	Colors = 16  // number of colors, the TInfo3 value
+)
+
+var (
+	ErrNotRIP  = errors.New("no ripscrip commands found")
+	ErrCommand = errors.New("unknown ripscrip command")
+	ErrArgs    = errors.New("invalid ripscrip arguments")
+	ErrType    = errors.New("record is not a ripscrip file type")
+	ErrWidth   = errors.New("ripscrip pixel width is not 640")
+	ErrHeight  = errors.New("ripscrip pixel height is not 350")
+	ErrColors  = errors.New("ripscrip number of colors is not 16")
+)
+
+// Command is a RIPscrip command.
+type Command struct {
+	Line  int    // line number of the command, starting from 1
+	Level int    // level of the command, where 0 are the graphic primitives
+	Name  byte   // name is the command character
+	Args  string // arguments of the command, with any escaped characters restored
+}
+
+func (c Command) String() string {
+	if c.Level > 0 {
+		return fmt.Sprintf("|%d%c%s", c.Level, c.Name, c.Args)
+	}
+	return fmt.Sprintf("|%c%s", c.Name, c.Args)
+}
+
+// Parse returns the commands of the RIPscrip stream b.
+// Lines of text that do not begin with an exclamation mark are ignored.
+// An error is returned for unknown level 0 commands and invalid arguments.
+func Parse(b []byte) ([]Command, error) {
+	cmds := []Command{}
+	for n, line := range lines(b) {
+		for _, seg := range segments(line) {
+			if seg == "" {
+				continue
+			}
+			c := command(seg, n+1)
+			if err := c.valid(); err != nil {
+				return cmds, err
+			}
+			cmds = append(cmds, c)
+		}
+	}
+	if len(cmds) == 0 {
+		return cmds, ErrNotRIP
+	}
+	return cmds, nil
+}
+
+// Check returns an error when the SAUCE record r is not a RIPscrip file type
+// or its type information is not 640 pixels wide, 350 pixels high and 16 colors.
+func Check(r *sauce.Record) error {
+	if r == nil || r.Data.Type != layout.Characters || layout.Character(r.File.Type) != layout.RipScript {
+		return ErrType
+	}
+	var errs []error
+	if v := r.Info.Info1.Value; v != Width {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrWidth, v))
+	}
+	if v := r.Info.Info2.Value; v != Height {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrHeight, v))
+	}
+	if v := r.Info.Info3.Value; v != Colors {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrColors, v))
+	}
+	return errors.Join(errs...)
+}
+
+// Validate returns an error when the RIPscrip stream b is invalid
+// or does not match the type information of the SAUCE record r.
+func Validate(b []byte, r *sauce.Record) error {
+	_, err := Parse(sauce.Trim(b))
+	return errors.Join(err, Check(r))
+}
+
+// lines returns the RIPscrip lines of b, which begin with an exclamation mark,
+// with any continued lines, that end with a backslash, joined together.
+// Lines that are not RIPscrip are kept as empty strings, to preserve the line numbers.
+func lines(b []byte) []string {
+	raw := strings.Split(string(bytes.ReplaceAll(b, []byte("\r\n"), []byte("\n"))), "\n")
+	out := make([]string, len(raw))
+	for i := 0; i < len(raw); i++ {
+		line := strings.TrimLeft(raw[i], "\x01\x02")
+		if !strings.HasPrefix(line, "!") {
+			continue
+		}
+		n := i
+		for strings.HasSuffix(line, `\`) && !strings.HasSuffix(line, `\\`) && i+1 < len(raw) {
+			i++
+			line = line[:len(line)-1] + raw[i]
+		}
+		out[n] = line[1:]
+	}
+	return out
+}
+
+// segments returns the commands of the line, which are separated by unescaped vertical bars.
+func segments(line string) []string {
+	segs := []string{}
+	var sb strings.Builder
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line):
+			i++
+			sb.WriteByte(line[i])
+		case c == '|':
+			segs = append(segs, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return append(segs, sb.String())
+}
+
+// command returns the command of the segment, which is found on line n.
+func command(seg string, n int) Command {
+	c := Command{Line: n}
+	i := 0
+	for i < len(seg) && seg[i] >= '0' && seg[i] <= '9' {
+		c.Level = c.Level*10 + int(seg[i]-'0')
+		i++
+	}
+	if i < len(seg) {
+		c.Name = seg[i]
+		c.Args = strings.TrimRight(seg[i+1:], " \t")
+	}
+	return c
+}
+
+// valid returns an error when the level 0 command c is unknown or has invalid arguments.
+// The commands of the other levels are not checked.
+func (c Command) valid() error {
+	if c.Level > 0 {
+		return nil
+	}
+	size, text, ok := spec(c.Name)
+	if !ok {
+		return fmt.Errorf("%w: line %d: %s", ErrCommand, c.Line, c)
+	}
+	a := newArgs(c.Args)
+	if poly(c.Name) {
+		size = 2 + 4*a.num(2)
+	}
+	if len(c.Args) < size || (!text && len(c.Args) > size) {
+		return fmt.Errorf("%w: line %d: %s", ErrArgs, c.Line, c)
+	}
+	for _, r := range c.Args[:size] {
+		if !isMega(byte(r)) {
+			return fmt.Errorf("%w: line %d: %s", ErrArgs, c.Line, c)
+		}
+	}
+	return nil
+}
+
+// spec returns the length of the MegaNum arguments of the level 0 command name,
+// and whether the arguments are followed by text.
+func spec(name byte) (int, bool, bool) {
+	switch name {
+	case '*', 'e', 'E', 'H', '>', '#':
+		return 0, false, true
+	case 'T':
+		return 0, true, true
+	case '@':
+		return 4, true, true
+	case 'c', 'W':
+		return 2, false, true
+	case 'g', 'a', 'm', 'X', 'S':
+		return 4, false, true
+	case 'F', 'C':
+		return 6, false, true
+	case 'v', 'Y', 'L', 'R', 'B', 'o', '=':
+		return 8, false, true
+	case 'w', 'A', 'I':
+		return 10, false, true
+	case 'O', 'V', 'i':
+		return 12, false, true
+	case 'Z', 's':
+		return 18, false, true
+	case 'Q':
+		return 32, false, true
+	case 'P', 'p', 'l':
+		return 2, false, true
+	}
+	return 0, false, false
+}
+
+// poly reports whether the command name uses a variable number of points.
+func poly(name byte) bool {
+	return name == 'P' || name == 'p' || name == 'l'
+}
+
+// isMega reports whether c is a MegaNum digit, 0-9 or A-Z.
+func isMega(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
+
+// args reads the MegaNum arguments of a command.
+type args struct {
+	s string
+	i int
+}
+
+func newArgs(s string) *args {
+	return &args{s: s}
+}
+
+// num returns the next MegaNum value of the width number of digits,
+// or 0 when there are not enough digits.
+func (a *args) num(width int) int {
+	if a.i+width > len(a.s) {
+		a.i = len(a.s)
+		return 0
+	}
+	n := 0
+	for _, c := range []byte(a.s[a.i : a.i+width]) {
+		n = n*36 + mega(c)
+	}
+	a.i += width
+	return n
+}
+
+// text returns the remaining arguments.
+func (a *args) text() string {
+	s := a.s[a.i:]
+	a.i = len(a.s)
+	return s
+}
+
+func mega(c byte) int {
+	const letters = 10
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'A' && c <= 'Z':
+		return int(c-'A') + letters
+	case c >= 'a' && c <= 'z':
+		return int(c-'a') + letters
+	}
+	return 0
+}
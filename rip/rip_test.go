@@ -0,0 +1,103 @@
+package rip_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/rip"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		b       string
+		want    []string
+		wantErr error
+	}{
+		{"line", "!|c0F|L00001010", []string{"|c0F", "|L00001010"}, nil},
+		{"text escape", `!|@0A0AOne \| Two`, []string{"|@0A0AOne | Two"}, nil},
+		{"continued", "!|L0000\\\r\n1010|#|#", []string{"|L00001010", "|#", "|#"}, nil},
+		{"level 1", "!|1K|1M00000010100000", []string{"|1K", "|1M00000010100000"}, nil},
+		{"ignored text", "Hello\r\n!|*", []string{"|*"}, nil},
+		{"polygon", "!|P0300000A000A0A", []string{"|P0300000A000A0A"}, nil},
+		{"no rip", "Hello world", nil, rip.ErrNotRIP},
+		{"unknown", "!|K0101", nil, rip.ErrCommand},
+		{"short", "!|L0000", nil, rip.ErrArgs},
+		{"long", "!|c0F0F", nil, rip.ErrArgs},
+		{"not meganum", "!|c#!", nil, rip.ErrArgs},
+		{"short polygon", "!|P0300000A", nil, rip.ErrArgs},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmds, err := rip.Parse([]byte(tt.b))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Parse() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if len(cmds) != len(tt.want) {
+				t.Errorf("Parse() = %v, want %v", cmds, tt.want)
+				return
+			}
+			for i, c := range cmds {
+				if got := c.String(); got != tt.want[i] {
+					t.Errorf("Parse()[%d] = %q, want %q", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParse_Line(t *testing.T) {
+	t.Parallel()
+	_, err := rip.Parse([]byte("!|*\r\n\r\n!|c0F|K"))
+	if err == nil || err.Error() != `unknown ripscrip command: line 3: |K` {
+		t.Errorf("Parse() error = %v, want the line number", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+	r := sauce.Record{}
+	r.Data.Type = 1
+	r.File.Type = 3
+	r.Info.Info1.Value = 640
+	r.Info.Info2.Value = 350
+	r.Info.Info3.Value = 16
+	if err := rip.Check(&r); err != nil {
+		t.Errorf("Check() error = %v, want nil", err)
+	}
+	r.Info.Info2.Value = 480
+	r.Info.Info3.Value = 256
+	err := rip.Check(&r)
+	if !errors.Is(err, rip.ErrHeight) || !errors.Is(err, rip.ErrColors) || errors.Is(err, rip.ErrWidth) {
+		t.Errorf("Check() error = %v, want height and colors errors", err)
+	}
+	r.File.Type = 1
+	if err := rip.Check(&r); !errors.Is(err, rip.ErrType) {
+		t.Errorf("Check() error = %v, want %v", err, rip.ErrType)
+	}
+	if err := rip.Validate([]byte("!|c0F"), nil); !errors.Is(err, rip.ErrType) {
+		t.Errorf("Validate() error = %v, want %v", err, rip.ErrType)
+	}
+}
+
+func ExampleParse() {
+	cmds, err := rip.Parse([]byte("!|c0E|L00009Q9Q|#"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, c := range cmds {
+		fmt.Printf("%c %q\n", c.Name, c.Args)
+	}
+	// Output: c "0E"
+	// L "00009Q9Q"
+	// # ""
+}
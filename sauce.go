@@ -91,7 +91,7 @@ func Trim(b []byte) []byte {
 		return b
 	}
 	// trim the eof marker
-	if b[pos-1] == EOF {
+	if pos > 0 && b[pos-1] == EOF {
 		return b[:pos-1]
 	}
 	return b[:pos]
@@ -111,6 +111,7 @@ type Record struct {
 	Info     layout.Infos   `json:"typeInfo" xml:"type_info"`    // file type dependant information
 	Desc     string         `json:"-"        xml:"-"`            // description of the file
 	Comnt    layout.Comment `json:"comments" xml:"comments"`     // comment block or notes
+	Index    int            `json:"-"        xml:"-"`            // index is the position of the SAUCE ID
 }
 
 // Decode the SAUCE data contained within b.
@@ -155,6 +156,7 @@ func Decode(b []byte) Record {
 				Index:   -1,
 				Comment: []string{},
 			},
+			Index: -1,
 		}
 	}
 	return Record{
@@ -170,9 +172,44 @@ func Decode(b []byte) Record {
 		Info:     d.InfoType(),
 		Desc:     d.Description(),
 		Comnt:    d.CommentBlock(),
+		Index:    Index(b),
 	}
 }
 
+// DecodeAll returns every SAUCE record stacked at the end of b.
+//
+// Some tools append a new record to a file that is already tagged,
+// leaving the earlier record and its optional comment block in place.
+// DecodeAll walks backwards through these records, so the first record
+// is the same as the one returned by [Decode] and the last record is the oldest.
+// The position of each record is kept in the Index field.
+// An empty slice is returned if b contains no SAUCE record.
+func DecodeAll(b []byte) []Record {
+	const none, sauceLen = -1, 128
+	recs := []Record{}
+	pos := Index(b)
+	for pos > none {
+		end := min(pos+sauceLen, len(b))
+		rec := Decode(b[:end])
+		recs = append(recs, rec)
+		// the content boundary is the start of the optional comnt block
+		boundary := pos
+		if ci := rec.Comnt.Index; ci > none && ci < pos {
+			boundary = ci
+		}
+		if boundary > 0 && b[boundary-1] == EOF {
+			boundary--
+		}
+		b = b[:boundary]
+		// a stacked record must end exactly at the content boundary
+		pos = none
+		if n := len(b) - sauceLen; n >= 0 && string(b[n:n+len(layout.SauceSeek)]) == layout.SauceSeek {
+			pos = n
+		}
+	}
+	return recs
+}
+
 // Read and return the SAUCE record in r.
 func Read(r io.Reader) (*Record, error) {
 	b, err := io.ReadAll(r)
@@ -139,3 +139,50 @@ func TestXML(t *testing.T) {
 		t.Errorf("Unmarshal Version got: %v, want %v", res.Version, ver)
 	}
 }
+
+func TestDecodeAll(t *testing.T) {
+	t.Parallel()
+	none := []byte("This is a string without any SAUCE.")
+	if got := sauce.DecodeAll(none); len(got) != 0 {
+		t.Errorf("DecodeAll() length = %d, want 0", len(got))
+	}
+	raw, err := static.ReadFile(example)
+	if err != nil {
+		t.Errorf("DecodeAll() %v error: %v", example, err)
+		return
+	}
+	if got := sauce.DecodeAll(raw); len(got) != 1 {
+		t.Errorf("DecodeAll() length = %d, want 1", len(got))
+	}
+	// append a second record to the tagged file
+	const sauceLen = 128
+	retag := []byte(strings.Repeat(" ", sauceLen))
+	copy(retag, raw[len(raw)-sauceLen:])
+	copy(retag[7:], "Second title")
+	retag[104] = 0 // no comment lines
+	stack := append([]byte{}, raw...)
+	stack = append(stack, sauce.EOF)
+	stack = append(stack, retag...)
+	got := sauce.DecodeAll(stack)
+	const wantL = 2
+	if len(got) != wantL {
+		t.Errorf("DecodeAll() length = %d, want %d", len(got), wantL)
+		return
+	}
+	const wantT0, wantT1 = "Second title", "Sauce title"
+	if got[0].Title != wantT0 {
+		t.Errorf("DecodeAll()[0].Title = %q, want %q", got[0].Title, wantT0)
+	}
+	if got[1].Title != wantT1 {
+		t.Errorf("DecodeAll()[1].Title = %q, want %q", got[1].Title, wantT1)
+	}
+	if want := len(raw) + 1; got[0].Index != want {
+		t.Errorf("DecodeAll()[0].Index = %d, want %d", got[0].Index, want)
+	}
+	if want := sauce.Index(raw); got[1].Index != want {
+		t.Errorf("DecodeAll()[1].Index = %d, want %d", got[1].Index, want)
+	}
+	if want := 1; got[1].Comnt.Count != want {
+		t.Errorf("DecodeAll()[1].Comnt.Count = %d, want %d", got[1].Comnt.Count, want)
+	}
+}
@@ -0,0 +1,118 @@
+package screen
+
+// Color is a palette index or a 24-bit RGB color value.
+//
+// The palette index uses the xterm 256 color order, where the first 8 colors
+// are the ANSI colors black, red, green, yellow, blue, magenta, cyan and white,
+// followed by their 8 high intensity variants.
+type Color uint32
+
+// Palette colors in ANSI order.
+const (
+	Black Color = iota
+	Red
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+	White
+)
+
+const (
+	rgbFlag Color = 1 << 24 // rgbflag marks a 24-bit color value
+	bright  Color = 8       // bright is the offset of the high intensity colors
+)
+
+// Index returns the color of the palette index i.
+func Index(i uint8) Color {
+	return Color(i)
+}
+
+// RGB returns the color of the 24-bit red, green and blue values.
+func RGB(r, g, b uint8) Color {
+	return rgbFlag | Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+// IsRGB reports whether c is a 24-bit color value, rather than a palette index.
+func (c Color) IsRGB() bool {
+	return c&rgbFlag != 0
+}
+
+// Index returns the palette index of c, or 0 when c is a 24-bit color.
+func (c Color) Index() uint8 {
+	if c.IsRGB() {
+		return 0
+	}
+	return uint8(c)
+}
+
+// RGB returns the red, green and blue values of the 24-bit color c.
+// Zero values are returned for a palette index.
+func (c Color) RGB() (uint8, uint8, uint8) {
+	if !c.IsRGB() {
+		return 0, 0, 0
+	}
+	return uint8(c >> 16), uint8(c >> 8), uint8(c)
+}
+
+// Attr are the colors and text attributes of a character cell.
+type Attr struct {
+	FG      Color // foreground color
+	BG      Color // background color
+	Bold    bool  // bold or high intensity foreground
+	Blink   bool  // blink, or a high intensity background in non-blink mode
+	Reverse bool  // reverse swaps the foreground and background colors
+	Conceal bool  // conceal hides the foreground
+}
+
+// Default are the light gray on black attributes of a text mode screen.
+func Default() Attr {
+	return Attr{FG: White, BG: Black}
+}
+
+// DOS returns the attributes of a DOS text mode attribute byte,
+// which is the format used by binary text and the PCBoard and Avatar color codes.
+//
+// The low nibble is the foreground color, the next 3 bits are the background color
+// and the high bit is blink. The color values use the CGA order of black, blue,
+// green, cyan, red, magenta, brown and light gray, which are converted to the ANSI order.
+func DOS(a uint8) Attr {
+	const intense, blink, mask = 0x08, 0x80, 0x07
+	fg := cga(a & mask)
+	if a&intense != 0 {
+		fg += bright
+	}
+	return Attr{
+		FG:    fg,
+		BG:    cga(a >> 4 & mask),
+		Blink: a&blink != 0,
+	}
+}
+
+// cga returns the ANSI order color of the CGA color value i.
+func cga(i uint8) Color {
+	return [...]Color{Black, Blue, Green, Cyan, Red, Magenta, Yellow, White}[i&7]
+}
+
+// Colors returns the displayed foreground and background colors of a.
+//
+// The bold attribute selects the high intensity variant of the first 8 foreground colors.
+// The blink attribute selects the high intensity variant of the first 8 background colors
+// when ice is true, which is the non-blink mode of the SAUCE ANSiFlags, also known as iCE colors.
+func (a Attr) Colors(ice bool) (Color, Color) {
+	fg, bg := a.FG, a.BG
+	if a.Bold && !fg.IsRGB() && fg < bright {
+		fg += bright
+	}
+	if ice && a.Blink && !bg.IsRGB() && bg < bright {
+		bg += bright
+	}
+	if a.Reverse {
+		fg, bg = bg, fg
+	}
+	if a.Conceal {
+		fg = bg
+	}
+	return fg, bg
+}
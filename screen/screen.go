@@ -0,0 +1,401 @@
+// Package screen emulates a text mode screen to play back ANSI and ASCII text.
+//
+// The screen is a grid of character cells with a fixed number of columns
+// and as many rows as the text requires. Text is written at the cursor,
+// which is moved by control codes and escape sequences, and automatically
+// wraps at the last column, like the MS-DOS ANSI.SYS driver.
+//
+// Playing back a file reveals its true dimensions and the colors it uses,
+// which can be compared to the SAUCE TInfo1 character width, TInfo2
+// number of lines and the ANSiFlags non-blink mode.
+package screen
+
+import (
+	"github.com/bengarrett/sauce/ansi"
+)
+
+const (
+	// Columns is the default number of columns of a text mode screen.
+	Columns = 80
+	// MaxColumns is the maximum number of columns of a screen.
+	MaxColumns = 4096
+	// MaxLines is the maximum number of lines of a screen.
+	MaxLines = 65536
+	// MaxCells is the maximum number of character cells of a screen,
+	// which limits the number of lines of a screen wider than the default columns.
+	MaxCells = Columns * MaxLines
+	// tabStop is the column interval of the horizontal tab stops.
+	tabStop = 8
+)
+
+// Cell is a character and its attributes.
+type Cell struct {
+	Char byte // char is the CP437 character
+	Attr Attr // attr are the colors and text attributes
+}
+
+// Stats are the measurements of the text played back on a screen.
+// Text that clears the screen, such as an animation, is measured by the largest of its frames.
+type Stats struct {
+	Columns   int  // columns is the width of the widest line of text, including any cleared text
+	Lines     int  // lines is the number of lines of text, including any cleared text
+	Blink     bool // blink is used by some text, which is a high intensity background in non-blink mode
+	Colors256 bool // colors256 are used by some text from the xterm 256 color palette
+	TrueColor bool // truecolor are used by some text from 24-bit RGB color values
+}
+
+// Screen is a text mode screen.
+type Screen struct {
+	width   int      // width is the number of columns before the text wraps
+	lines   int      // lines is the maximum number of rows
+	rows    [][]Cell // rows of character cells, which are allocated when written
+	x, y    int      // x and y are the cursor column and row, starting from 0
+	saveX   int      // saved cursor column
+	saveY   int      // saved cursor row
+	attr    Attr     // attr is the current character attributes
+	wrap    bool     // wrap is the automatic line wrapping mode
+	pending bool     // pending is a line wrap after a character is written to the last column
+	done    bool     // done is set after the end-of-file marker
+	stats   Stats
+}
+
+// New returns a blank screen with the number of columns.
+// A zero or negative width uses the default of 80 columns.
+// The screen has up to [MaxLines] rows, or fewer when the rows would exceed [MaxCells].
+func New(width int) *Screen {
+	if width <= 0 {
+		width = Columns
+	}
+	width = min(width, MaxColumns)
+	return &Screen{
+		width: width,
+		lines: min(MaxLines, MaxCells/width),
+		attr:  Default(),
+		wrap:  true,
+	}
+}
+
+// Load plays back the ANSI text b on a new screen with the number of columns.
+func Load(b []byte, width int) *Screen {
+	s := New(width)
+	s.Play(ansi.Tokenize(b))
+	return s
+}
+
+// Measure returns the measurements of the ANSI text b played back on a screen
+// with the number of columns.
+func Measure(b []byte, width int) Stats {
+	return Load(b, width).Stats()
+}
+
+// Width returns the number of columns of the screen.
+func (s *Screen) Width() int {
+	return s.width
+}
+
+// Height returns the number of rows that have been written.
+func (s *Screen) Height() int {
+	return len(s.rows)
+}
+
+// Stats returns the measurements of the text written to the screen.
+func (s *Screen) Stats() Stats {
+	return s.stats
+}
+
+// Cell returns the character cell at column x and row y.
+// A blank cell is returned for cells that have not been written.
+func (s *Screen) Cell(x, y int) Cell {
+	if y < 0 || y >= len(s.rows) || x < 0 || x >= s.width || s.rows[y] == nil {
+		return Cell{Char: ' ', Attr: Default()}
+	}
+	return s.rows[y][x]
+}
+
+// Row returns the character cells of row y.
+// A nil slice is returned for rows that have not been written.
+func (s *Screen) Row(y int) []Cell {
+	if y < 0 || y >= len(s.rows) {
+		return nil
+	}
+	return s.rows[y]
+}
+
+// Play writes the tokens to the screen.
+// Playback stops at the SUB end-of-file marker.
+func (s *Screen) Play(toks []ansi.Token) {
+	for _, t := range toks {
+		if s.done {
+			return
+		}
+		s.token(t)
+	}
+}
+
+func (s *Screen) token(t ansi.Token) {
+	switch t.Kind {
+	case ansi.Text:
+		for _, c := range t.Raw {
+			s.print(c)
+		}
+	case ansi.Control:
+		s.control(t.Final)
+	case ansi.SGR:
+		s.sgr(t.Params)
+	case ansi.RGB:
+		s.rgb(t)
+	case ansi.CursorUp, ansi.CursorDown, ansi.CursorForward, ansi.CursorBack,
+		ansi.CursorPosition, ansi.CursorColumn, ansi.Save, ansi.Restore:
+		s.cursor(t)
+	case ansi.EraseDisplay:
+		s.eraseDisplay(t.Param(0, 0))
+	case ansi.EraseLine:
+		s.eraseLine(t.Param(0, 0))
+	case ansi.Mode:
+		const autowrap = 7
+		if t.Param(0, 0) == autowrap {
+			s.wrap = t.Final == 'h'
+		}
+	case ansi.Music, ansi.Unknown:
+		return
+	}
+}
+
+func (s *Screen) control(c byte) {
+	s.pending = false
+	switch c {
+	case ansi.CR:
+		s.x = 0
+	case ansi.LF:
+		s.x = 0
+		s.move(0, 1)
+	case ansi.BS:
+		s.move(-1, 0)
+	case ansi.TAB:
+		s.x = min((s.x/tabStop+1)*tabStop, s.width-1)
+	case ansi.SUB:
+		s.done = true
+	}
+}
+
+func (s *Screen) cursor(t ansi.Token) {
+	s.pending = false
+	n := t.Param(0, 1)
+	switch t.Kind {
+	case ansi.CursorUp:
+		s.move(0, -n)
+	case ansi.CursorDown:
+		s.move(0, n)
+	case ansi.CursorForward:
+		s.move(n, 0)
+	case ansi.CursorBack:
+		s.move(-n, 0)
+	case ansi.CursorPosition:
+		s.x, s.y = 0, 0
+		s.move(t.Param(1, 1)-1, n-1)
+	case ansi.CursorColumn:
+		s.x = 0
+		s.move(n-1, 0)
+	case ansi.Save:
+		s.saveX, s.saveY = s.x, s.y
+	case ansi.Restore:
+		s.x, s.y = s.saveX, s.saveY
+	}
+}
+
+// move the cursor by the number of columns and rows, within the bounds of the screen.
+func (s *Screen) move(cols, rows int) {
+	s.x = max(0, min(s.x+cols, s.width-1))
+	s.y = max(0, min(s.y+rows, s.lines-1))
+}
+
+// print writes the character c at the cursor and advances the cursor.
+func (s *Screen) print(c byte) {
+	if s.pending {
+		s.pending = false
+		s.x = 0
+		s.move(0, 1)
+	}
+	s.put(s.x, s.y, Cell{Char: c, Attr: s.attr})
+	if s.x < s.width-1 {
+		s.x++
+		return
+	}
+	s.pending = s.wrap
+}
+
+// Put writes the character cell c to column x and row y, without moving the cursor.
+// Cells outside of the screen are ignored.
+func (s *Screen) Put(x, y int, c Cell) {
+	if x < 0 || x >= s.width || y < 0 || y >= s.lines {
+		return
+	}
+	s.put(x, y, c)
+}
+
+func (s *Screen) put(x, y int, c Cell) {
+	row := s.row(y)
+	row[x] = c
+	s.stats.Columns = max(s.stats.Columns, x+1)
+	s.stats.Lines = max(s.stats.Lines, y+1)
+	if c.Attr.Blink {
+		s.stats.Blink = true
+	}
+}
+
+// row returns the cells of row y, allocating any unwritten rows.
+func (s *Screen) row(y int) []Cell {
+	for len(s.rows) <= y {
+		s.rows = append(s.rows, nil)
+	}
+	if s.rows[y] == nil {
+		s.rows[y] = s.blank()
+	}
+	return s.rows[y]
+}
+
+func (s *Screen) blank() []Cell {
+	row := make([]Cell, s.width)
+	for i := range row {
+		row[i] = Cell{Char: ' ', Attr: Default()}
+	}
+	return row
+}
+
+// erase blanks the cells of row y from column x0 up to, but not including, column x1.
+func (s *Screen) erase(y, x0, x1 int) {
+	if y >= len(s.rows) || s.rows[y] == nil {
+		return
+	}
+	blank := Cell{Char: ' ', Attr: Attr{FG: s.attr.FG, BG: s.attr.BG}}
+	for x := max(0, x0); x < min(x1, s.width); x++ {
+		s.rows[y][x] = blank
+	}
+}
+
+func (s *Screen) eraseDisplay(n int) {
+	const toEnd, toStart, all = 0, 1, 2
+	switch n {
+	case toEnd:
+		s.erase(s.y, s.x, s.width)
+		for y := s.y + 1; y < len(s.rows); y++ {
+			s.erase(y, 0, s.width)
+		}
+	case toStart:
+		for y := range s.y {
+			s.erase(y, 0, s.width)
+		}
+		s.erase(s.y, 0, s.x+1)
+	case all:
+		// the stats keep the largest area written before the screen was cleared
+		s.rows = nil
+		s.x, s.y = 0, 0
+	}
+}
+
+func (s *Screen) eraseLine(n int) {
+	const toEnd, toStart, all = 0, 1, 2
+	switch n {
+	case toEnd:
+		s.erase(s.y, s.x, s.width)
+	case toStart:
+		s.erase(s.y, 0, s.x+1)
+	case all:
+		s.erase(s.y, 0, s.width)
+	}
+}
+
+// sgr applies the select graphic rendition parameters to the current attributes.
+func (s *Screen) sgr(params []int) {
+	if len(params) == 0 {
+		s.attr = Default()
+		return
+	}
+	for i := 0; i < len(params); i++ {
+		p := params[i]
+		switch {
+		case p == 38 || p == 48:
+			i += s.extended(p, params[i+1:])
+		case p >= 30 && p <= 37:
+			s.attr.FG = Color(p - 30)
+		case p >= 40 && p <= 47:
+			s.attr.BG = Color(p - 40)
+		case p >= 90 && p <= 97:
+			s.attr.FG = Color(p-90) + bright
+		case p >= 100 && p <= 107:
+			s.attr.BG = Color(p-100) + bright
+		default:
+			s.attribute(p)
+		}
+	}
+}
+
+func (s *Screen) attribute(p int) {
+	switch p {
+	case 0:
+		s.attr = Default()
+	case 1:
+		s.attr.Bold = true
+	case 5, 6:
+		s.attr.Blink = true
+	case 7:
+		s.attr.Reverse = true
+	case 8:
+		s.attr.Conceal = true
+	case 21, 22:
+		s.attr.Bold = false
+	case 25:
+		s.attr.Blink = false
+	case 27:
+		s.attr.Reverse = false
+	case 28:
+		s.attr.Conceal = false
+	case 39:
+		s.attr.FG = White
+	case 49:
+		s.attr.BG = Black
+	}
+}
+
+// extended applies the 38 foreground or 48 background extended color parameters
+// and returns the number of parameters that were used.
+func (s *Screen) extended(p int, params []int) int {
+	const palette, truecolor = 5, 2
+	var c Color
+	used := 0
+	switch {
+	case len(params) >= 2 && params[0] == palette:
+		c = Index(byte8(params[1]))
+		s.stats.Colors256 = true
+		used = 2
+	case len(params) >= 4 && params[0] == truecolor:
+		c = RGB(byte8(params[1]), byte8(params[2]), byte8(params[3]))
+		s.stats.TrueColor = true
+		used = 4
+	default:
+		return len(params)
+	}
+	if p == 38 {
+		s.attr.FG = c
+		return used
+	}
+	s.attr.BG = c
+	return used
+}
+
+// rgb applies the PabloDraw 24-bit color sequence, ESC[0;R;G;Bt for the background
+// and ESC[1;R;G;Bt for the foreground.
+func (s *Screen) rgb(t ansi.Token) {
+	const fg = 1
+	c := RGB(byte8(t.Params[1]), byte8(t.Params[2]), byte8(t.Params[3]))
+	s.stats.TrueColor = true
+	if t.Params[0] == fg {
+		s.attr.FG = c
+		return
+	}
+	s.attr.BG = c
+}
+
+func byte8(i int) uint8 {
+	return uint8(max(0, min(i, 255))) //nolint:gosec
+}
@@ -0,0 +1,184 @@
+package screen_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bengarrett/sauce/screen"
+)
+
+func TestMeasure(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		b     string
+		width int
+		want  screen.Stats
+	}{
+		{"empty", "", 80, screen.Stats{}},
+		{"hello", "Hello", 80, screen.Stats{Columns: 5, Lines: 1}},
+		{"newlines", "Hi\r\n\r\nHello\r\n", 80, screen.Stats{Columns: 5, Lines: 3}},
+		{"lf only", "Hi\nHello\n", 80, screen.Stats{Columns: 5, Lines: 2}},
+		{"full line", strings.Repeat("x", 80) + "\r\nHi", 80, screen.Stats{Columns: 80, Lines: 2}},
+		{"wrap", strings.Repeat("x", 100), 80, screen.Stats{Columns: 80, Lines: 2}},
+		{"wide", strings.Repeat("x", 100), 160, screen.Stats{Columns: 100, Lines: 1}},
+		{"default width", strings.Repeat("x", 81), 0, screen.Stats{Columns: 80, Lines: 2}},
+		{"cursor forward", "\x1b[10CHi", 80, screen.Stats{Columns: 12, Lines: 1}},
+		{"cursor position", "\x1b[5;20HHi", 80, screen.Stats{Columns: 21, Lines: 5}},
+		{"trailing skip", "Hi\x1b[70C\r\n", 80, screen.Stats{Columns: 2, Lines: 1}},
+		{"sub", "Hi\x1aHello world", 80, screen.Stats{Columns: 2, Lines: 1}},
+		{"blink", "\x1b[5;44mHi", 80, screen.Stats{Columns: 2, Lines: 1, Blink: true}},
+		{"256", "\x1b[38;5;202mHi", 80, screen.Stats{Columns: 2, Lines: 1, Colors256: true}},
+		{"truecolor", "\x1b[48;2;10;20;30mHi", 80, screen.Stats{Columns: 2, Lines: 1, TrueColor: true}},
+		{"pablodraw", "\x1b[1;10;20;30tHi", 80, screen.Stats{Columns: 2, Lines: 1, TrueColor: true}},
+		{"clear", "Hello\x1b[2JHi", 80, screen.Stats{Columns: 5, Lines: 1}},
+		{"frames", "Hello\r\nworld\r\n!\x1b[2JHi\x1b[2J", 80, screen.Stats{Columns: 5, Lines: 3}},
+		{"no wrap", "\x1b[?7l" + strings.Repeat("x", 100), 80, screen.Stats{Columns: 80, Lines: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := screen.Measure([]byte(tt.b), tt.width); got != tt.want {
+				t.Errorf("Measure() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	s := screen.Load([]byte("\x1b[1;31;45mA\x1b[0mB\x1b[s\r\n\x1b[uC\tD\x1b[1;1HE"), 80)
+	if got, want := s.Width(), 80; got != want {
+		t.Errorf("Width() = %d, want %d", got, want)
+	}
+	if got, want := s.Height(), 1; got != want {
+		t.Errorf("Height() = %d, want %d", got, want)
+	}
+	tests := []struct {
+		x, y int
+		char byte
+		attr screen.Attr
+	}{
+		{0, 0, 'E', screen.Default()},
+		{1, 0, 'B', screen.Default()},
+		{2, 0, 'C', screen.Default()},
+		{8, 0, 'D', screen.Default()},
+		{0, 5, ' ', screen.Default()},
+	}
+	for _, tt := range tests {
+		c := s.Cell(tt.x, tt.y)
+		if c.Char != tt.char {
+			t.Errorf("Cell(%d, %d).Char = %q, want %q", tt.x, tt.y, c.Char, tt.char)
+		}
+		if c.Attr != tt.attr {
+			t.Errorf("Cell(%d, %d).Attr = %+v, want %+v", tt.x, tt.y, c.Attr, tt.attr)
+		}
+	}
+	s = screen.Load([]byte("\x1b[1;31;45mA"), 80)
+	want := screen.Attr{FG: screen.Red, BG: screen.Magenta, Bold: true}
+	if got := s.Cell(0, 0).Attr; got != want {
+		t.Errorf("Cell(0, 0).Attr = %+v, want %+v", got, want)
+	}
+	s = screen.Load([]byte("Hello\x1b[1;3H\x1b[K"), 80)
+	if got, want := s.Cell(1, 0).Char, byte('e'); got != want {
+		t.Errorf("Cell(1, 0).Char = %q, want %q", got, want)
+	}
+	if got, want := s.Cell(2, 0).Char, byte(' '); got != want {
+		t.Errorf("Cell(2, 0).Char = %q, want %q", got, want)
+	}
+	if got := s.Row(1); got != nil {
+		t.Errorf("Row(1) = %v, want nil", got)
+	}
+}
+
+func TestAttr_Colors(t *testing.T) {
+	t.Parallel()
+	rgb := screen.RGB(1, 2, 3)
+	tests := []struct {
+		name   string
+		attr   screen.Attr
+		ice    bool
+		wantFG screen.Color
+		wantBG screen.Color
+	}{
+		{"default", screen.Default(), false, screen.White, screen.Black},
+		{"bold", screen.Attr{FG: screen.Red, Bold: true}, false, screen.Red + 8, screen.Black},
+		{"blink", screen.Attr{BG: screen.Blue, Blink: true}, false, screen.Black, screen.Blue},
+		{"ice", screen.Attr{BG: screen.Blue, Blink: true}, true, screen.Black, screen.Blue + 8},
+		{"reverse", screen.Attr{FG: screen.Red, BG: screen.Blue, Reverse: true}, false, screen.Blue, screen.Red},
+		{"rgb bold", screen.Attr{FG: rgb, Bold: true}, false, rgb, screen.Black},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fg, bg := tt.attr.Colors(tt.ice)
+			if fg != tt.wantFG || bg != tt.wantBG {
+				t.Errorf("Colors() = %v, %v, want %v, %v", fg, bg, tt.wantFG, tt.wantBG)
+			}
+		})
+	}
+}
+
+func TestColor(t *testing.T) {
+	t.Parallel()
+	c := screen.RGB(10, 20, 30)
+	if !c.IsRGB() {
+		t.Error("IsRGB() = false, want true")
+	}
+	if r, g, b := c.RGB(); r != 10 || g != 20 || b != 30 {
+		t.Errorf("RGB() = %d, %d, %d, want 10, 20, 30", r, g, b)
+	}
+	if got := c.Index(); got != 0 {
+		t.Errorf("Index() = %d, want 0", got)
+	}
+	i := screen.Index(202)
+	if i.IsRGB() {
+		t.Error("IsRGB() = true, want false")
+	}
+	if got := i.Index(); got != 202 {
+		t.Errorf("Index() = %d, want 202", got)
+	}
+}
+
+func TestDOS(t *testing.T) {
+	t.Parallel()
+	// bright white on blue, blinking
+	a := screen.DOS(0x9f)
+	if a.FG != screen.White+8 || a.BG != screen.Blue || !a.Blink {
+		t.Errorf("DOS(0x9f) = %+v, want a blinking bright white on blue", a)
+	}
+	// brown on black
+	if a := screen.DOS(0x06); a.FG != screen.Yellow || a.BG != screen.Black || a.Blink {
+		t.Errorf("DOS(0x06) = %+v, want yellow on black", a)
+	}
+}
+
+func TestScreen_Put(t *testing.T) {
+	t.Parallel()
+	s := screen.New(4)
+	s.Put(3, 2, screen.Cell{Char: 'Z', Attr: screen.Default()})
+	s.Put(4, 0, screen.Cell{Char: 'X', Attr: screen.Default()})
+	if got := s.Cell(3, 2).Char; got != 'Z' {
+		t.Errorf("Cell(3, 2) = %q, want %q", got, 'Z')
+	}
+	if st := s.Stats(); st.Columns != 4 || st.Lines != 3 {
+		t.Errorf("Stats() = %+v, want 4 columns and 3 lines", st)
+	}
+}
+
+func TestScreen_MaxCells(t *testing.T) {
+	t.Parallel()
+	const lines = screen.MaxCells / screen.MaxColumns
+	s := screen.New(screen.MaxColumns)
+	s.Put(0, lines, screen.Cell{Char: 'X', Attr: screen.Default()})
+	if h := s.Height(); h != 0 {
+		t.Errorf("Height() after a put beyond the cells = %d, want 0", h)
+	}
+	st := screen.Measure([]byte("\x1b[65535BHi"), screen.MaxColumns)
+	if st.Lines != lines {
+		t.Errorf("Measure() lines = %d, want %d", st.Lines, lines)
+	}
+	if st := screen.Measure([]byte("\x1b[65535BHi"), screen.Columns); st.Lines != screen.MaxLines {
+		t.Errorf("Measure() lines = %d, want %d", st.Lines, screen.MaxLines)
+	}
+}
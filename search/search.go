@@ -0,0 +1,295 @@
+// Package search is an in-process full-text search of SAUCE records and the text of artwork.
+//
+// An [Index] holds the words of the title, author, group and comments of each record,
+// and the plain text of character based artwork, which is played back on a screen
+// to remove the ANSI escape codes and is decoded from the IBM PC code page 437.
+// Searches match every word and quoted phrase of a query, and the results are ranked
+// by relevance, where the words of the record fields are worth more than the text of the artwork.
+package search
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/bengarrett/sauce"
+)
+
+// Field is the part of a file where the words are found.
+type Field uint8
+
+const (
+	Title    Field = iota // title of the SAUCE record
+	Author                // author of the SAUCE record
+	Group                 // group of the SAUCE record
+	Comments              // comments of the SAUCE record
+	Content               // content is the text of the artwork
+)
+
+func (f Field) String() string {
+	if f > Content {
+		return ""
+	}
+	return [...]string{"title", "author", "group", "comments", "content"}[f]
+}
+
+// weight returns the relevance of a word found in the field.
+func (f Field) weight() float64 {
+	const record, comment, content = 3, 2, 1
+	switch f {
+	case Title, Author, Group:
+		return record
+	case Comments:
+		return comment
+	case Content:
+	}
+	return content
+}
+
+// BM25 ranking parameters.
+const (
+	k1 = 1.2  // k1 is the saturation of the term frequency
+	b  = 0.75 // b is the normalization of the document length
+)
+
+// Result is a file that matches a search.
+type Result struct {
+	Path   string       // path of the file
+	Record sauce.Record // record of the file, which is empty when the file is not tagged
+	Score  float64      // score is the relevance of the file, where a higher score is more relevant
+	Fields []Field      // fields are where the words of the search were found
+}
+
+// posting is the positions of a word in a field of a document.
+type posting struct {
+	doc   int
+	field Field
+	pos   []int
+}
+
+// doc is a document of the index.
+type doc struct {
+	path   string
+	record sauce.Record
+	length float64 // length is the weighted number of words
+}
+
+// Index is an inverted index of the words of SAUCE records and artwork.
+// An Index is safe for concurrent use by multiple goroutines.
+type Index struct {
+	mu       sync.RWMutex
+	docs     []doc
+	postings map[string][]posting
+	total    float64 // total is the weighted number of words of every document
+}
+
+// New returns an empty index.
+func New() *Index {
+	return &Index{postings: map[string][]posting{}}
+}
+
+// Len returns the number of files in the index.
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.docs)
+}
+
+// Add indexes the file b that has the path.
+// The SAUCE record and the text of character based artwork are indexed,
+// where files without a record use the type of content found by [sauce.Sniff].
+func (ix *Index) Add(path string, b []byte) {
+	var r sauce.Record
+	if sauce.Contains(b) {
+		if rec := sauce.Decode(b); rec.Valid() {
+			r = rec
+		}
+	}
+	ix.AddRecord(path, &r, Text(b, &r))
+}
+
+// AddRecord indexes the SAUCE record r and the plain text content of a file that has the path.
+// It can be used to index records that are read from a catalog, where the content is empty.
+func (ix *Index) AddRecord(path string, r *sauce.Record, content string) {
+	fields := [...]string{
+		Title:    r.Title,
+		Author:   r.Author,
+		Group:    r.Group,
+		Comments: strings.Join(r.Comnt.Comment, "\n"),
+		Content:  content,
+	}
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	id := len(ix.docs)
+	d := doc{path: path, record: *r}
+	for f, s := range fields {
+		field := Field(f) //nolint:gosec
+		positions := map[string][]int{}
+		words := Tokenize(s)
+		for i, w := range words {
+			positions[w] = append(positions[w], i)
+		}
+		for w, pos := range positions {
+			ix.postings[w] = append(ix.postings[w], posting{doc: id, field: field, pos: pos})
+		}
+		d.length += float64(len(words)) * field.weight()
+	}
+	ix.docs = append(ix.docs, d)
+	ix.total += d.length
+}
+
+// Tokenize returns the lowercase words of s, which are sequences of letters and digits.
+func Tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// Search returns the files that contain every word and quoted phrase of the query,
+// ordered by their relevance. The words of a phrase, such as "dark nebula",
+// must be found next to each other in the same field. A limit of 0 or less returns every result.
+func (ix *Index) Search(query string, limit int) []Result {
+	terms := parse(query)
+	if len(terms) == 0 {
+		return []Result{}
+	}
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	scores := map[int]float64{}
+	fields := map[int]map[Field]bool{}
+	for i, term := range terms {
+		hits := ix.match(term)
+		if i > 0 {
+			// every term must match, so the documents without a hit are removed
+			for id := range scores {
+				if _, ok := hits[id]; !ok {
+					delete(scores, id)
+				}
+			}
+		}
+		idf := ix.idf(len(hits))
+		for id, tf := range hits {
+			if _, ok := scores[id]; !ok && i > 0 {
+				continue
+			}
+			scores[id] += idf * ix.saturate(id, tf.weighted)
+			if fields[id] == nil {
+				fields[id] = map[Field]bool{}
+			}
+			for _, f := range tf.fields {
+				fields[id][f] = true
+			}
+		}
+	}
+	results := make([]Result, 0, len(scores))
+	for id, score := range scores {
+		d := ix.docs[id]
+		fs := make([]Field, 0, len(fields[id]))
+		for f := range fields[id] {
+			fs = append(fs, f)
+		}
+		slices.Sort(fs)
+		results = append(results, Result{Path: d.path, Record: d.record, Score: score, Fields: fs})
+	}
+	slices.SortFunc(results, func(a, b Result) int {
+		if n := cmp.Compare(b.Score, a.Score); n != 0 {
+			return n
+		}
+		return strings.Compare(a.Path, b.Path)
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// parse returns the terms of the query, where each term is a word or the words of a quoted phrase.
+func parse(query string) [][]string {
+	terms := [][]string{}
+	for i, part := range strings.Split(query, `"`) {
+		words := Tokenize(part)
+		if i%2 == 1 {
+			// the odd parts are within quotes
+			if len(words) > 0 {
+				terms = append(terms, words)
+			}
+			continue
+		}
+		for _, w := range words {
+			terms = append(terms, []string{w})
+		}
+	}
+	return terms
+}
+
+// frequency is the number of times a term is found in a document.
+type frequency struct {
+	weighted float64 // weighted is the number of times the term is found, multiplied by the field weights
+	fields   []Field
+}
+
+// match returns the frequency of the words of the term in each document,
+// where the words must be next to each other in the same field.
+func (ix *Index) match(words []string) map[int]*frequency {
+	hits := map[int]*frequency{}
+	type key struct {
+		doc   int
+		field Field
+	}
+	// the positions of the following words of the phrase
+	next := make([]map[key][]int, len(words))
+	for i, w := range words[1:] {
+		next[i+1] = map[key][]int{}
+		for _, p := range ix.postings[w] {
+			next[i+1][key{p.doc, p.field}] = p.pos
+		}
+	}
+	for _, p := range ix.postings[words[0]] {
+		n := 0
+		for _, start := range p.pos {
+			if phrase(next, key{p.doc, p.field}, start) {
+				n++
+			}
+		}
+		if n == 0 {
+			continue
+		}
+		tf := hits[p.doc]
+		if tf == nil {
+			tf = &frequency{}
+			hits[p.doc] = tf
+		}
+		tf.weighted += float64(n) * p.field.weight()
+		tf.fields = append(tf.fields, p.field)
+	}
+	return hits
+}
+
+// phrase reports whether the following words of a phrase are found after the start position.
+func phrase[K comparable](next []map[K][]int, k K, start int) bool {
+	for i := 1; i < len(next); i++ {
+		if _, ok := slices.BinarySearch(next[i][k], start+i); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// idf returns the inverse document frequency of a term found in n documents.
+func (ix *Index) idf(n int) float64 {
+	docs := float64(len(ix.docs))
+	return math.Log(1 + (docs-float64(n)+0.5)/(float64(n)+0.5))
+}
+
+// saturate returns the BM25 term frequency of the weighted frequency tf in the document.
+func (ix *Index) saturate(id int, tf float64) float64 {
+	avg := ix.total / float64(len(ix.docs))
+	norm := 1.0
+	if avg > 0 {
+		norm = 1 - b + b*ix.docs[id].length/avg
+	}
+	return tf * (k1 + 1) / (tf + k1*norm)
+}
@@ -0,0 +1,142 @@
+package search_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/fixture"
+	"github.com/bengarrett/sauce/internal/layout"
+	"github.com/bengarrett/sauce/search"
+)
+
+func index() *search.Index {
+	nebula := fixture.ANSI("Dark Nebula", "Zed", "ACiD", 1996)
+	logo := fixture.ANSI("Logo", "Amy", "iCE", 1996)
+	logo.Comnt.Comment = []string{"greets to the dark side"}
+	stars := fixture.ANSI("Stars", "Zed", "Fire", 1996)
+	ix := search.New()
+	ix.AddRecord("nebula.ans", &nebula, "a dark cloud of stars")
+	ix.AddRecord("logo.ans", &logo, "ice logo")
+	ix.AddRecord("stars.ans", &stars, "nebula dark sky full of stars")
+	ix.AddRecord("readme.txt", &sauce.Record{}, "read me first")
+	return ix
+}
+
+func paths(results []search.Result) []string {
+	s := make([]string, 0, len(results))
+	for _, r := range results {
+		s = append(s, r.Path)
+	}
+	return s
+}
+
+func TestIndex_Search(t *testing.T) {
+	t.Parallel()
+	ix := index()
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"empty", "  ", []string{}},
+		{"unknown", "cyan", []string{}},
+		{"title ranks first", "nebula", []string{"nebula.ans", "stars.ans"}},
+		{"case insensitive, shorter first", "ZED", []string{"stars.ans", "nebula.ans"}},
+		{"every word", "zed stars", []string{"stars.ans", "nebula.ans"}},
+		{"every word missing", "zed logo", []string{}},
+		{"phrase", `"dark nebula"`, []string{"nebula.ans"}},
+		{"reversed phrase", `"nebula dark"`, []string{"stars.ans"}},
+		{"phrase across fields", `"nebula zed"`, []string{}},
+		{"comments", "greets", []string{"logo.ans"}},
+		{"content", "first", []string{"readme.txt"}},
+		{"punctuation", "read-me!", []string{"readme.txt"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := paths(ix.Search(tt.query, 0)); !slices.Equal(got, tt.want) {
+				t.Errorf("Search(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+	if got := ix.Search("dark", 1); len(got) != 1 {
+		t.Errorf("Search() with a limit = %d results, want 1", len(got))
+	}
+	res := ix.Search("dark", 0)
+	if len(res) != 3 || res[0].Path != "nebula.ans" {
+		t.Fatalf("Search(dark) = %q, want nebula.ans first", paths(res))
+	}
+	if want := []search.Field{search.Title, search.Content}; !slices.Equal(res[0].Fields, want) {
+		t.Errorf("Search(dark) fields = %v, want %v", res[0].Fields, want)
+	}
+	if res[0].Record.Author != "Zed" {
+		t.Errorf("Search(dark) record author = %q, want Zed", res[0].Record.Author)
+	}
+}
+
+func TestIndex_Add(t *testing.T) {
+	t.Parallel()
+	ix := search.New()
+	ix.Add("plain.ans", []byte("\x1b[1;31mHELLO\x1b[0m \x1b[5Cworld\r\n\x1b[33mgoodbye\x1a"))
+	ix.Add("sound.bin", []byte{0x00, 0x01, 'h', 'e', 'l', 'l', 'o'})
+	if n := ix.Len(); n != 2 {
+		t.Errorf("Len() = %d, want 2", n)
+	}
+	if got := paths(ix.Search("hello world goodbye", 0)); !slices.Equal(got, []string{"plain.ans"}) {
+		t.Errorf("Search() = %q, want plain.ans", got)
+	}
+	if got := paths(ix.Search("31m", 0)); len(got) != 0 {
+		t.Errorf("Search() escape code = %q, want none", got)
+	}
+}
+
+func TestText(t *testing.T) {
+	t.Parallel()
+	ans := fixture.ANSI("", "", "", 1996)
+	tests := []struct {
+		name string
+		b    []byte
+		r    *sauce.Record
+		want string
+	}{
+		{"ansi", []byte("\x1b[1mA\x1b[0m\x1b[2CB\r\nC  "), &ans, "A  B\nC"},
+		{"cp437", []byte("caf\x82 \xdb"), &ans, "café █"},
+		{"sniffed", []byte("\x1b[31mred\x1b[0m"), nil, "red"},
+		{"binary text", []byte{'h', 0x07, 'i', 0x07}, &sauce.Record{
+			Data: layout.Datas{Type: layout.BinaryTexts},
+			File: layout.Files{Type: 1},
+		}, "hi"},
+		{"bitmap", []byte("GIF89a"), nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := search.Text(tt.b, tt.r); got != tt.want {
+				t.Errorf("Text() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+	got := search.Tokenize("Greets to ACiD, iCE & Fire-Crew (1996)!")
+	want := []string{"greets", "to", "acid", "ice", "fire", "crew", "1996"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Tokenize() = %q, want %q", got, want)
+	}
+}
+
+func ExampleIndex_Search() {
+	ix := search.New()
+	ix.AddRecord("nebula.ans", &sauce.Record{Title: "Dark Nebula", Group: "ACiD"}, "")
+	ix.AddRecord("stars.ans", &sauce.Record{Title: "Stars", Group: "iCE"}, "a dark nebula")
+	for _, res := range ix.Search(`"dark nebula"`, 0) {
+		fmt.Println(res.Path, res.Fields)
+	}
+	// Output:
+	// nebula.ans [title]
+	// stars.ans [content]
+}
@@ -0,0 +1,61 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/cp437"
+	"github.com/bengarrett/sauce/internal/layout"
+	"github.com/bengarrett/sauce/render"
+)
+
+// Text returns the plain text of the character based artwork b, which is played back
+// on a screen to remove the ANSI escape codes and is decoded from the IBM PC code page 437.
+// The rows of the screen are separated by newlines, without their trailing spaces.
+//
+// The data type and file type of the SAUCE record r are used to play back the artwork,
+// or when r is nil or empty, the type found by [sauce.Sniff]. An empty string is returned
+// for files that are not ASCII, ANSI, PCBoard, Avatar, TundraDraw, binary text or XBin.
+func Text(b []byte, r *sauce.Record) string {
+	var rec sauce.Record
+	if r != nil {
+		rec = *r
+	}
+	if rec.Data.Type == layout.Nones {
+		rec.Data.Type, rec.File.Type, _ = sauce.Sniff(b)
+	}
+	if !text(rec.Data.Type, rec.File.Type) {
+		return ""
+	}
+	s, _, err := render.Load(b, &rec)
+	if err != nil {
+		return ""
+	}
+	rows := make([]string, 0, s.Height())
+	var sb strings.Builder
+	for y := range s.Height() {
+		sb.Reset()
+		for _, c := range s.Row(y) {
+			sb.WriteRune(cp437.Rune(c.Char))
+		}
+		rows = append(rows, strings.TrimRight(sb.String(), " "))
+	}
+	return strings.TrimRight(strings.Join(rows, "\n"), "\n")
+}
+
+// text reports whether the data type and file type is text that can be played back on a screen.
+func text(dt layout.TypeOfData, ft layout.TypeOfFile) bool {
+	switch dt {
+	case layout.Characters:
+		switch layout.Character(ft) {
+		case layout.ASCII, layout.Ansi, layout.AnsiMation, layout.Source,
+			layout.PCBoard, layout.Avatar, layout.TundraDraw:
+			return true
+		case layout.RipScript, layout.HTML:
+		}
+	case layout.BinaryTexts, layout.XBins:
+		return true
+	case layout.Nones, layout.Bitmaps, layout.Vectors, layout.Audios, layout.Archives, layout.Executables:
+	}
+	return false
+}
@@ -0,0 +1,372 @@
+package sauce
+
+import (
+	"bytes"
+
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+// Confidence is the certainty of a content match returned by [Sniff].
+type Confidence uint8
+
+const (
+	ConfidenceUnknown Confidence = iota // the content was not recognized
+	ConfidenceLow                       // a heuristic match, such as the density of control codes
+	ConfidenceMedium                    // a short or partial signature match
+	ConfidenceHigh                      // a complete magic number or signature match
+)
+
+func (c Confidence) String() string {
+	if c > ConfidenceHigh {
+		return ""
+	}
+	return [...]string{
+		"unknown",
+		"low",
+		"medium",
+		"high",
+	}[c]
+}
+
+// signature is a content match for a SAUCE DataType and FileType pair.
+type signature struct {
+	data  layout.TypeOfData
+	file  layout.TypeOfFile
+	conf  Confidence
+	match func(b []byte) bool
+}
+
+// Sniff infers the SAUCE DataType and FileType of b from its content.
+//
+// Sniff recognizes the magic numbers and headers of the bitmap, archive,
+// audio, vector and executable formats that SAUCE enumerates, the XBin,
+// TundraDraw and RIPscrip headers, HTML markup and the control codes used
+// by ANSI, PCBoard and Avatar text. Any SAUCE metadata in b is ignored.
+//
+// The Confidence reports how the match was made. Magic numbers return [ConfidenceHigh],
+// while text that is identified by the density of its control codes returns [ConfidenceLow].
+// If b is not recognized, Sniff returns zero values and [ConfidenceUnknown].
+func Sniff(b []byte) (DataType, FileType, Confidence) {
+	b = Trim(b)
+	if len(b) == 0 {
+		return layout.Nones, 0, ConfidenceUnknown
+	}
+	for _, sig := range signatures() {
+		if sig.match(b) {
+			return sig.data, sig.file, sig.conf
+		}
+	}
+	return sniffText(b)
+}
+
+func signatures() []signature {
+	sigs := make([]signature, 0, 64)
+	sigs = append(sigs, bitmapSigs()...)
+	sigs = append(sigs, archiveSigs()...)
+	sigs = append(sigs, audioSigs()...)
+	sigs = append(sigs, otherSigs()...)
+	return sigs
+}
+
+func bitmapSigs() []signature {
+	bmp := func(ft layout.Bitmap, c Confidence, fn func([]byte) bool) signature {
+		return signature{layout.Bitmaps, layout.TypeOfFile(ft), c, fn}
+	}
+	return []signature{
+		bmp(layout.Gif, ConfidenceHigh, func(b []byte) bool {
+			return prefix(b, "GIF87a") || prefix(b, "GIF89a")
+		}),
+		bmp(layout.Png, ConfidenceHigh, func(b []byte) bool { return prefix(b, "\x89PNG\r\n\x1a\n") }),
+		bmp(layout.Jpg, ConfidenceHigh, func(b []byte) bool { return prefix(b, "\xff\xd8\xff") }),
+		bmp(layout.Bmp, ConfidenceHigh, isBMP),
+		bmp(layout.Lbm, ConfidenceHigh, func(b []byte) bool {
+			return prefix(b, "FORM") && (at(b, 8, "ILBM") || at(b, 8, "PBM "))
+		}),
+		bmp(layout.Avi, ConfidenceHigh, func(b []byte) bool { return prefix(b, "RIFF") && at(b, 8, "AVI ") }),
+		bmp(layout.Fli, ConfidenceMedium, func(b []byte) bool { return at(b, 4, "\x11\xaf") }),
+		bmp(layout.Flc, ConfidenceMedium, func(b []byte) bool { return at(b, 4, "\x12\xaf") }),
+		bmp(layout.Tga, ConfidenceHigh, func(b []byte) bool {
+			const footer = "TRUEVISION-XFILE.\x00"
+			return bytes.HasSuffix(b, []byte(footer))
+		}),
+		bmp(layout.Mpg, ConfidenceMedium, func(b []byte) bool {
+			return prefix(b, "\x00\x00\x01\xba") || prefix(b, "\x00\x00\x01\xb3")
+		}),
+		bmp(layout.Pcx, ConfidenceMedium, isPCX),
+	}
+}
+
+func archiveSigs() []signature {
+	arc := func(ft layout.Archive, c Confidence, fn func([]byte) bool) signature {
+		return signature{layout.Archives, layout.TypeOfFile(ft), c, fn}
+	}
+	return []signature{
+		arc(layout.Zip, ConfidenceHigh, func(b []byte) bool {
+			return prefix(b, "PK\x03\x04") || prefix(b, "PK\x05\x06")
+		}),
+		arc(layout.Rar, ConfidenceHigh, func(b []byte) bool { return prefix(b, "Rar!\x1a\x07") }),
+		arc(layout.Lzh, ConfidenceHigh, func(b []byte) bool {
+			const lha = 7
+			return len(b) > lha && b[2] == '-' && b[6] == '-' &&
+				(at(b, 3, "lh") || at(b, 3, "lz"))
+		}),
+		arc(layout.Zoo, ConfidenceHigh, func(b []byte) bool {
+			return prefix(b, "ZOO ") && at(b, 20, "\xdc\xa7\xc4\xfd")
+		}),
+		arc(layout.Uc2, ConfidenceHigh, func(b []byte) bool { return prefix(b, "UC2\x1a") }),
+		arc(layout.Sqz, ConfidenceHigh, func(b []byte) bool { return prefix(b, "HLSQZ") }),
+		arc(layout.Tar, ConfidenceHigh, func(b []byte) bool { return at(b, 257, "ustar") }),
+		arc(layout.Arj, ConfidenceMedium, func(b []byte) bool { return prefix(b, "\x60\xea") }),
+		arc(layout.Pak, ConfidenceMedium, func(b []byte) bool {
+			const crunched, squashed = 10, 11
+			return isARC(b) && (b[1] == crunched || b[1] == squashed)
+		}),
+		arc(layout.Arc, ConfidenceMedium, isARC),
+	}
+}
+
+func audioSigs() []signature {
+	aud := func(ft layout.Audio, c Confidence, fn func([]byte) bool) signature {
+		return signature{layout.Audios, layout.TypeOfFile(ft), c, fn}
+	}
+	return []signature{
+		aud(layout.Xm, ConfidenceHigh, func(b []byte) bool { return prefix(b, "Extended Module: ") }),
+		aud(layout.It, ConfidenceHigh, func(b []byte) bool { return prefix(b, "IMPM") }),
+		aud(layout.S3m, ConfidenceHigh, func(b []byte) bool { return at(b, 44, "SCRM") }),
+		aud(layout.Mtm, ConfidenceHigh, func(b []byte) bool { return prefix(b, "MTM") && len(b) > 3 && b[3] == 0x10 }),
+		aud(layout.Stm, ConfidenceHigh, func(b []byte) bool {
+			return (at(b, 20, "!Scream!") || at(b, 20, "BMOD2STM")) && at(b, 28, "\x1a")
+		}),
+		aud(layout.Far, ConfidenceHigh, func(b []byte) bool { return prefix(b, "FAR\xfe") }),
+		aud(layout.Ult, ConfidenceHigh, func(b []byte) bool { return prefix(b, "MAS_UTrack_V00") }),
+		aud(layout.Okt, ConfidenceHigh, func(b []byte) bool { return prefix(b, "OKTASONG") }),
+		aud(layout.Dmf, ConfidenceHigh, func(b []byte) bool { return prefix(b, "DDMF") }),
+		aud(layout.Amf, ConfidenceMedium, func(b []byte) bool { return prefix(b, "AMF") }),
+		aud(layout.Midi, ConfidenceHigh, func(b []byte) bool { return prefix(b, "MThd") }),
+		aud(layout.Wave, ConfidenceHigh, func(b []byte) bool { return prefix(b, "RIFF") && at(b, 8, "WAVE") }),
+		aud(layout.Voc, ConfidenceHigh, func(b []byte) bool { return prefix(b, "Creative Voice File\x1a") }),
+		aud(layout.Cmf, ConfidenceHigh, func(b []byte) bool { return prefix(b, "CTMF") }),
+		aud(layout.Rol, ConfidenceHigh, func(b []byte) bool { return at(b, 4, "\\roll\\default") }),
+		aud(layout.Patch16, ConfidenceHigh, func(b []byte) bool { return isPatch(b) && patch16(b) }),
+		aud(layout.Patch8, ConfidenceHigh, isPatch),
+		aud(layout.Mod, ConfidenceHigh, isMOD),
+		aud(layout.Composer669, ConfidenceMedium, is669),
+	}
+}
+
+func otherSigs() []signature {
+	return []signature{
+		{layout.XBins, layout.TypeOfFile(layout.ExtendedBin), ConfidenceHigh, func(b []byte) bool {
+			return prefix(b, "XBIN\x1a")
+		}},
+		{layout.Characters, layout.TypeOfFile(layout.TundraDraw), ConfidenceHigh, func(b []byte) bool {
+			return prefix(b, "\x18TUNDRA24")
+		}},
+		{layout.Vectors, layout.TypeOfFile(layout.Dwg), ConfidenceHigh, func(b []byte) bool {
+			return prefix(b, "AC10") || prefix(b, "AC1.")
+		}},
+		{layout.Vectors, layout.TypeOfFile(layout.Dxf), ConfidenceMedium, func(b []byte) bool {
+			s := bytes.TrimLeft(b, " \r\n")
+			return prefix(s, "0\r\nSECTION") || prefix(s, "0\nSECTION")
+		}},
+		{layout.Executables, layout.TypeOfFile(layout.Exe), ConfidenceHigh, func(b []byte) bool {
+			return prefix(b, "MZ") || prefix(b, "\x7fELF")
+		}},
+	}
+}
+
+// sniffText identifies character based files from their markup and control codes.
+func sniffText(b []byte) (layout.TypeOfData, layout.TypeOfFile, Confidence) {
+	chr := func(ft layout.Character, c Confidence) (layout.TypeOfData, layout.TypeOfFile, Confidence) {
+		return layout.Characters, layout.TypeOfFile(ft), c
+	}
+	const sample = 512
+	head := bytes.ToLower(bytes.TrimSpace(b[:min(len(b), sample)]))
+	switch {
+	case prefix(head, "<!doctype html"), prefix(head, "<html"):
+		return chr(layout.HTML, ConfidenceHigh)
+	case isRIP(b):
+		return chr(layout.RipScript, ConfidenceMedium)
+	}
+	if !isText(b) {
+		return layout.Nones, 0, ConfidenceUnknown
+	}
+	const esc, avt, dle = "\x1b[", "\x16\x01", "\x16"
+	escapes := bytes.Count(b, []byte(esc))
+	pcb := pcboardCodes(b)
+	avatar := bytes.Count(b, []byte(avt)) + bytes.Count(b, []byte("\x19"))
+	switch {
+	case pcb > 0 && pcb >= escapes:
+		return chr(layout.PCBoard, density(b, pcb))
+	case avatar > 0 && avatar >= escapes && bytes.Contains(b, []byte(dle)):
+		return chr(layout.Avatar, density(b, avatar))
+	case escapes > 0:
+		return chr(layout.Ansi, density(b, escapes))
+	case bytes.Contains(head, []byte("<html")), bytes.Contains(head, []byte("<body")):
+		return chr(layout.HTML, ConfidenceMedium)
+	}
+	return chr(layout.ASCII, ConfidenceLow)
+}
+
+// density returns the confidence of a text match based on the frequency of its control codes.
+func density(b []byte, codes int) Confidence {
+	const perBytes, dense = 1000, 5
+	if codes*perBytes/len(b) >= dense {
+		return ConfidenceMedium
+	}
+	return ConfidenceLow
+}
+
+// isText reports whether b is likely to be CP437 text, which permits all bytes
+// except for the rarely used C0 control codes.
+// The arguments of the Avatar control codes are skipped as they can be any value.
+func isText(b []byte) bool {
+	const sample, tolerance, args = 4096, 100, 2
+	s := b[:min(len(b), sample)]
+	bad := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 0x20, c == '\t', c == '\n', c == '\r', c == EOF, c == 0x1b, c == 0x0c, c == 0x0e:
+			continue
+		case c == 0x16, c == 0x19:
+			i += args
+		case c == 0:
+			bad += tolerance
+		default:
+			bad++
+		}
+	}
+	return bad*tolerance <= len(s)
+}
+
+// pcboardCodes returns the number of PCBoard @X color codes in b.
+func pcboardCodes(b []byte) int {
+	const code = "@X"
+	n := 0
+	for i := bytes.Index(b, []byte(code)); i > -1 && i+3 < len(b); {
+		if isHex(b[i+2]) && isHex(b[i+3]) {
+			n++
+		}
+		j := bytes.Index(b[i+1:], []byte(code))
+		if j == -1 {
+			break
+		}
+		i += j + 1
+	}
+	return n
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'A' && c <= 'F') || (c >= 'a' && c <= 'f')
+}
+
+// isRIP reports whether b begins with a RIPscrip command, which may follow some ANSI codes.
+func isRIP(b []byte) bool {
+	const sample = 256
+	s := b[:min(len(b), sample)]
+	for line := range bytes.Lines(s) {
+		if prefix(bytes.TrimLeft(line, "\x1b[0123456789;!"), "|") && bytes.Contains(line, []byte("!|")) {
+			return true
+		}
+	}
+	return false
+}
+
+func isBMP(b []byte) bool {
+	const dib = 14
+	if !prefix(b, "BM") || len(b) < dib+1 {
+		return false
+	}
+	switch b[dib] {
+	case 12, 40, 52, 56, 64, 108, 124: // known DIB header sizes
+		return true
+	}
+	return false
+}
+
+func isPCX(b []byte) bool {
+	const manufacturer, header = 0x0a, 128
+	if len(b) < header || b[0] != manufacturer {
+		return false
+	}
+	version, encoding, bpp := b[1], b[2], b[3]
+	switch version {
+	case 0, 2, 3, 4, 5:
+	default:
+		return false
+	}
+	switch bpp {
+	case 1, 2, 4, 8:
+	default:
+		return false
+	}
+	return encoding <= 1
+}
+
+// isARC reports whether b begins with a SEA ARC header.
+func isARC(b []byte) bool {
+	const marker, name, maxMethod = 0x1a, 15, 0x14
+	if len(b) < name || b[0] != marker || b[1] == 0 || b[1] > maxMethod {
+		return false
+	}
+	// the filename is a nul terminated, 13 byte ASCII string
+	for _, c := range b[2:name] {
+		if c == 0 {
+			return true
+		}
+		if c < 0x20 || c > 0x7e {
+			return false
+		}
+	}
+	return false
+}
+
+func isMOD(b []byte) bool {
+	const offset = 1080
+	if len(b) < offset+4 {
+		return false
+	}
+	sig := string(b[offset : offset+4])
+	switch sig {
+	case "M.K.", "M!K!", "M&K!", "N.T.", "FLT4", "FLT8", "CD81", "OKTA", "OCTA":
+		return true
+	}
+	// xCHN and xxCH are the FastTracker channel signatures
+	if sig[1:] == "CHN" && sig[0] >= '1' && sig[0] <= '9' {
+		return true
+	}
+	return sig[2:] == "CH" && isDigit(sig[0]) && isDigit(sig[1])
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func is669(b []byte) bool {
+	const header, maxSamples, maxPatterns = 0x1f1, 64, 128
+	if len(b) < header || !(prefix(b, "if") || prefix(b, "JN")) {
+		return false
+	}
+	const samples, patterns = 0x6e, 0x6f
+	return b[samples] <= maxSamples && b[patterns] <= maxPatterns
+}
+
+func isPatch(b []byte) bool {
+	return prefix(b, "GF1PATCH110") || prefix(b, "GF1PATCH100")
+}
+
+// patch16 reports whether the first wave sample in a Gravis patch uses 16-bit data.
+func patch16(b []byte) bool {
+	const modes = 294
+	return len(b) > modes && b[modes]&1 == 1
+}
+
+func prefix(b []byte, s string) bool {
+	return bytes.HasPrefix(b, []byte(s))
+}
+
+// at reports whether s is found at offset i of b.
+func at(b []byte, i int, s string) bool {
+	return len(b) >= i+len(s) && string(b[i:i+len(s)]) == s
+}
@@ -0,0 +1,88 @@
+package sauce_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bengarrett/sauce"
+)
+
+func pad(s string, offset, size int) []byte {
+	b := make([]byte, size)
+	copy(b[offset:], s)
+	return b
+}
+
+func TestSniff(t *testing.T) {
+	t.Parallel()
+	raw, err := static.ReadFile(example)
+	if err != nil {
+		t.Errorf("Sniff() %v error: %v", example, err)
+		return
+	}
+	const (
+		chr = sauce.DataCharacter
+		bmp = sauce.DataBitmap
+		aud = sauce.DataAudio
+		arc = sauce.DataArchive
+	)
+	tests := []struct {
+		name     string
+		b        []byte
+		wantData sauce.DataType
+		wantFile sauce.FileType
+		wantConf sauce.Confidence
+	}{
+		{"empty", nil, sauce.DataNone, 0, sauce.ConfidenceUnknown},
+		{"binary", []byte("\x00\x01\x02\x03\x04\x05"), sauce.DataNone, 0, sauce.ConfidenceUnknown},
+		{"gif", []byte("GIF89a\x10\x00\x10\x00"), bmp, sauce.BitmapGif, sauce.ConfidenceHigh},
+		{"png", []byte("\x89PNG\r\n\x1a\n\x00\x00"), bmp, sauce.BitmapPng, sauce.ConfidenceHigh},
+		{"jpeg", []byte("\xff\xd8\xff\xe0\x00\x10JFIF"), bmp, sauce.BitmapJpg, sauce.ConfidenceHigh},
+		{"lbm", []byte("FORM\x00\x00\x00\x00ILBMBMHD"), bmp, sauce.BitmapLbm, sauce.ConfidenceHigh},
+		{"zip", []byte("PK\x03\x04\x14\x00"), arc, sauce.ArchiveZip, sauce.ConfidenceHigh},
+		{"lha", []byte("\x00\x00-lh5-\x00\x00"), arc, sauce.ArchiveLzh, sauce.ConfidenceHigh},
+		{"rar", []byte("Rar!\x1a\x07\x00"), arc, sauce.ArchiveRar, sauce.ConfidenceHigh},
+		{"arc", []byte("\x1a\x08FILE.TXT\x00\x00\x00\x00\x00\x00"), arc, sauce.ArchiveArc, sauce.ConfidenceMedium},
+		{"xm", []byte("Extended Module: song"), aud, sauce.AudioXm, sauce.ConfidenceHigh},
+		{"s3m", pad("SCRM", 44, 96), aud, sauce.AudioS3m, sauce.ConfidenceHigh},
+		{"mod", pad("M.K.", 1080, 1084), aud, sauce.AudioMod, sauce.ConfidenceHigh},
+		{"mod 8ch", pad("8CHN", 1080, 1084), aud, sauce.AudioMod, sauce.ConfidenceHigh},
+		{"midi", []byte("MThd\x00\x00\x00\x06"), aud, sauce.AudioMidi, sauce.ConfidenceHigh},
+		{"wave", []byte("RIFF\x00\x00\x00\x00WAVEfmt "), aud, sauce.AudioWave, sauce.ConfidenceHigh},
+		{"xbin", []byte("XBIN\x1a\x50\x00\x19\x00"), sauce.DataXBin, 0, sauce.ConfidenceHigh},
+		{"tundra", []byte("\x18TUNDRA24"), chr, sauce.CharacterTundraDraw, sauce.ConfidenceHigh},
+		{"exe", []byte("MZ\x90\x00"), sauce.DataExecutable, 0, sauce.ConfidenceHigh},
+		{"html", []byte("  <!DOCTYPE html><html></html>"), chr, sauce.CharacterHTML, sauce.ConfidenceHigh},
+		{"rip", []byte("!|*|c0F|L00001010\r\n"), chr, sauce.CharacterRipScript, sauce.ConfidenceMedium},
+		{"ansi", []byte("\x1b[0;1;31mHello\x1b[0m world"), chr, sauce.CharacterAnsi, sauce.ConfidenceMedium},
+		{"pcboard", []byte("@X0FHello @X1Eworld"), chr, sauce.CharacterPCBoard, sauce.ConfidenceMedium},
+		{"avatar", []byte("\x16\x01\x0fHello \x19-\x10"), chr, sauce.CharacterAvatar, sauce.ConfidenceMedium},
+		{"sauce ascii", raw, chr, sauce.CharacterASCII, sauce.ConfidenceLow},
+		{"sparse ansi", []byte("\x1b[0m" + strings.Repeat("Hello world. ", 100)), chr, sauce.CharacterAnsi, sauce.ConfidenceLow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dt, ft, c := sauce.Sniff(tt.b)
+			if dt != tt.wantData {
+				t.Errorf("Sniff() data = %v, want %v", dt, tt.wantData)
+			}
+			if ft != tt.wantFile {
+				t.Errorf("Sniff() file = %v, want %v", ft, tt.wantFile)
+			}
+			if c != tt.wantConf {
+				t.Errorf("Sniff() confidence = %v, want %v", c, tt.wantConf)
+			}
+		})
+	}
+}
+
+func TestConfidence_String(t *testing.T) {
+	t.Parallel()
+	if got, want := sauce.ConfidenceHigh.String(), "high"; got != want {
+		t.Errorf("Confidence.String() = %q, want %q", got, want)
+	}
+	if got := sauce.Confidence(99).String(); got != "" {
+		t.Errorf("Confidence.String() = %q, want an empty string", got)
+	}
+}
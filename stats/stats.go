@@ -0,0 +1,278 @@
+// Package stats aggregates the SAUCE records of a collection of files into reports.
+//
+// A [Tally] counts the records by group, author, year, data type, file type, font,
+// non-blink mode (iCE colors) and letter-spacing, and totals their file sizes and comment lines.
+// The resulting [Report] can be written as text tables, JSON or CSV.
+package stats
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/humanize"
+	"github.com/bengarrett/sauce/internal/layout"
+	"golang.org/x/text/language"
+)
+
+var ErrFormat = errors.New("unknown report format")
+
+// Format is the output of a report.
+type Format uint8
+
+const (
+	Text Format = iota // text tables
+	JSON               // an indented JSON object
+	CSV                // comma-separated values with a header row
+)
+
+func (f Format) String() string {
+	if f > CSV {
+		return ""
+	}
+	return [...]string{"text", "json", "csv"}[f]
+}
+
+// ParseFormat returns the format of the name, which is "text", "json" or "csv".
+func ParseFormat(name string) (Format, error) {
+	for f := Text; f <= CSV; f++ {
+		if strings.EqualFold(name, f.String()) {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrFormat, name)
+}
+
+// Names of the tables of a report, which match the fields of a [sauce.Compile] query.
+const (
+	Group         = "group"
+	Author        = "author"
+	Year          = "year"
+	DataType      = "datatype"
+	FileType      = "filetype"
+	Font          = "font"
+	NonBlink      = "nonblink"
+	LetterSpacing = "letterspacing"
+)
+
+// tables returns the names of the tables in the order of a report.
+func tables() []string {
+	return []string{Group, Author, Year, DataType, FileType, Font, NonBlink, LetterSpacing}
+}
+
+// Row is the number of records that share a value, with the totals of their SAUCE FileSize and comment lines.
+type Row struct {
+	Value    string `json:"value"`        // value of the field, which is empty when it is not set
+	Files    int    `json:"files"`        // files is the number of records
+	FileSize uint64 `json:"fileSize"`     // file size is the total of the SAUCE FileSize values in bytes
+	Comments int    `json:"commentLines"` // comments are the total number of comment lines
+}
+
+func (r *Row) add(rec *sauce.Record) {
+	r.Files++
+	r.FileSize += uint64(rec.FileSize.Bytes)
+	r.Comments += len(rec.Comnt.Comment)
+}
+
+// Table is the rows of a field, ordered by the most files,
+// except for the year table that is in chronological order.
+type Table struct {
+	Name string `json:"name"`
+	Rows []Row  `json:"rows"`
+}
+
+// Report is the aggregate of the SAUCE records of a collection.
+type Report struct {
+	Total    Row     `json:"total"`    // total of every record
+	Untagged int     `json:"untagged"` // untagged is the number of files without a record
+	Tables   []Table `json:"tables"`
+}
+
+// Tally counts SAUCE records. The zero value is ready to use.
+// A Tally is not safe for concurrent use.
+type Tally struct {
+	total    Row
+	untagged int
+	rows     map[string]map[string]*Row
+}
+
+// Add counts the record r, where a nil or invalid record is counted as an untagged file.
+//
+// The non-blink mode and letter-spacing are only counted for the character,
+// binary text and XBin data types, which are the records that use the ANSiFlags.
+func (t *Tally) Add(r *sauce.Record) {
+	if r == nil || !r.Valid() {
+		t.untagged++
+		return
+	}
+	if t.rows == nil {
+		t.rows = map[string]map[string]*Row{}
+	}
+	t.total.add(r)
+	t.add(Group, strings.TrimSpace(r.Group), r)
+	t.add(Author, strings.TrimSpace(r.Author), r)
+	year := ""
+	if !r.Date.Time.IsZero() {
+		year = strconv.Itoa(r.Date.Time.Year())
+	}
+	t.add(Year, year, r)
+	t.add(DataType, r.Data.Name, r)
+	t.add(FileType, r.File.Name, r)
+	t.add(Font, strings.TrimSpace(r.Info.Font), r)
+	switch r.Data.Type {
+	case layout.Characters, layout.BinaryTexts, layout.XBins:
+		t.add(NonBlink, strconv.FormatBool(r.Info.Flags.NonBlink()), r)
+		t.add(LetterSpacing, letterSpacing(r.Info.Flags.LetterSpacing()), r)
+	case layout.Nones, layout.Bitmaps, layout.Vectors, layout.Audios, layout.Archives, layout.Executables:
+	}
+}
+
+func (t *Tally) add(table, value string, r *sauce.Record) {
+	rows := t.rows[table]
+	if rows == nil {
+		rows = map[string]*Row{}
+		t.rows[table] = rows
+	}
+	row := rows[value]
+	if row == nil {
+		row = &Row{Value: value}
+		rows[value] = row
+	}
+	row.add(r)
+}
+
+// letterSpacing returns the name of the letter-spacing value used by a query.
+func letterSpacing(ls layout.LS) string {
+	switch ls {
+	case layout.LSNone:
+		return "none"
+	case layout.LS8px:
+		return "8px"
+	case layout.LS9px:
+		return "9px"
+	case layout.LSReserved:
+	}
+	return "reserved"
+}
+
+// Report returns the report of the records that have been counted.
+func (t *Tally) Report() Report {
+	r := Report{Total: t.total, Untagged: t.untagged, Tables: []Table{}}
+	r.Total.Value = "total"
+	for _, name := range tables() {
+		rows := make([]Row, 0, len(t.rows[name]))
+		for _, row := range t.rows[name] {
+			rows = append(rows, *row)
+		}
+		slices.SortFunc(rows, func(a, b Row) int {
+			if name != Year {
+				if n := cmp.Compare(b.Files, a.Files); n != 0 {
+					return n
+				}
+			}
+			return strings.Compare(a.Value, b.Value)
+		})
+		r.Tables = append(r.Tables, Table{Name: name, Rows: rows})
+	}
+	return r
+}
+
+// Collect returns the report of the records.
+func Collect(records []sauce.Record) Report {
+	var t Tally
+	for i := range records {
+		t.Add(&records[i])
+	}
+	return t.Report()
+}
+
+// Write writes the report to w in the format.
+func (r Report) Write(w io.Writer, f Format) error {
+	switch f {
+	case Text:
+		return r.WriteText(w)
+	case JSON:
+		return r.WriteJSON(w)
+	case CSV:
+		return r.WriteCSV(w)
+	}
+	return fmt.Errorf("%w: %d", ErrFormat, f)
+}
+
+// WriteJSON writes the report to w as an indented JSON object.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r); err != nil {
+		return fmt.Errorf("write json report: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV writes the report to w as comma-separated values. The first rows
+// are the totals of the tagged and untagged files, followed by the rows of each table.
+func (r Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	row := func(table string, x Row) []string {
+		return []string{
+			table, x.Value, strconv.Itoa(x.Files),
+			strconv.FormatUint(x.FileSize, 10), strconv.Itoa(x.Comments),
+		}
+	}
+	tagged := r.Total
+	tagged.Value = "tagged"
+	records := [][]string{
+		{"table", "value", "files", "filesize", "comments"},
+		row("total", tagged),
+		row("total", Row{Value: "untagged", Files: r.Untagged}),
+	}
+	for _, t := range r.Tables {
+		for _, x := range t.Rows {
+			records = append(records, row(t.Name, x))
+		}
+	}
+	if err := cw.WriteAll(records); err != nil {
+		return fmt.Errorf("write csv report: %w", err)
+	}
+	return nil
+}
+
+// WriteText writes the report to w as text tables, where the file sizes
+// are in decimal units and the empty values are shown as "(none)".
+func (r Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	size := func(n uint64) string {
+		return humanize.Decimal(int64(min(n, 1<<63-1)), language.English) //nolint:gosec
+	}
+	fmt.Fprintf(tw, "files\t%d\t\n", r.Total.Files+r.Untagged)
+	fmt.Fprintf(tw, "tagged\t%d\t\n", r.Total.Files)
+	fmt.Fprintf(tw, "untagged\t%d\t\n", r.Untagged)
+	fmt.Fprintf(tw, "file size\t%s\t\n", size(r.Total.FileSize))
+	fmt.Fprintf(tw, "comment lines\t%d\t\n", r.Total.Comments)
+	for _, t := range r.Tables {
+		if len(t.Rows) == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "\n%s\tfiles\t%%\tfile size\tcomments\t\n", t.Name)
+		for _, x := range t.Rows {
+			value := x.Value
+			if value == "" {
+				value = "(none)"
+			}
+			pct := 100 * float64(x.Files) / float64(max(r.Total.Files, 1))
+			fmt.Fprintf(tw, "%s\t%d\t%.1f\t%s\t%d\t\n", value, x.Files, pct, size(x.FileSize), x.Comments)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("write text report: %w", err)
+	}
+	return nil
+}
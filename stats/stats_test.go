@@ -0,0 +1,160 @@
+package stats_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/fixture"
+	"github.com/bengarrett/sauce/internal/layout"
+	"github.com/bengarrett/sauce/stats"
+)
+
+func records() []sauce.Record {
+	logo := fixture.ANSI("", "Zed", "ACiD", 1996)
+	logo.Info.Flags = layout.Flags(0b10101).Parse()
+	logo.Info.Font = "IBM VGA"
+	logo.Comnt.Comment = []string{"greets", "to all"}
+	gif := fixture.ANSI("", "Amy", "ACiD", 1995)
+	gif.Data = layout.Datas{Type: layout.Bitmaps, Name: layout.Bitmaps.String()}
+	gif.File = layout.Files{Type: layout.TypeOfFile(layout.Gif), Name: layout.Gif.String()}
+	gif.Info.Flags = layout.Flags(0b10001).Parse()
+	recs := []sauce.Record{logo, fixture.ANSI("", "Amy", "iCE", 1996), gif}
+	for i := range recs {
+		recs[i].FileSize.Bytes = 1000
+	}
+	return append(recs, sauce.Record{})
+}
+
+func table(r stats.Report, name string) []stats.Row {
+	for _, t := range r.Tables {
+		if t.Name == name {
+			return t.Rows
+		}
+	}
+	return nil
+}
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+	r := stats.Collect(records())
+	if want := (stats.Row{Value: "total", Files: 3, FileSize: 3000, Comments: 2}); r.Total != want {
+		t.Errorf("Total = %+v, want %+v", r.Total, want)
+	}
+	if r.Untagged != 1 {
+		t.Errorf("Untagged = %d, want 1", r.Untagged)
+	}
+	tests := []struct {
+		table string
+		want  []string
+		files []int
+	}{
+		{stats.Group, []string{"ACiD", "iCE"}, []int{2, 1}},
+		{stats.Author, []string{"Amy", "Zed"}, []int{2, 1}},
+		{stats.Year, []string{"1995", "1996"}, []int{1, 2}},
+		{stats.DataType, []string{"text or character stream", "bitmap graphic or animation"}, []int{2, 1}},
+		{stats.FileType, []string{layout.Ansi.String(), layout.Gif.String()}, []int{2, 1}},
+		{stats.Font, []string{"", "IBM VGA"}, []int{2, 1}},
+		{stats.NonBlink, []string{"false", "true"}, []int{1, 1}},
+		{stats.LetterSpacing, []string{"8px", "none"}, []int{1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.table, func(t *testing.T) {
+			t.Parallel()
+			rows := table(r, tt.table)
+			values, files := []string{}, []int{}
+			for _, row := range rows {
+				values = append(values, row.Value)
+				files = append(files, row.Files)
+			}
+			if !slices.Equal(values, tt.want) || !slices.Equal(files, tt.files) {
+				t.Errorf("%s = %q %d, want %q %d", tt.table, values, files, tt.want, tt.files)
+			}
+		})
+	}
+}
+
+func TestReport_Write(t *testing.T) {
+	t.Parallel()
+	r := stats.Collect(records())
+	var buf bytes.Buffer
+	if err := r.Write(&buf, stats.JSON); err != nil {
+		t.Fatal(err)
+	}
+	var got stats.Report
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Total != r.Total || len(got.Tables) != len(r.Tables) {
+		t.Errorf("JSON report = %+v, want %+v", got, r)
+	}
+	buf.Reset()
+	if err := r.Write(&buf, stats.CSV); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	for i, want := range []string{
+		"table,value,files,filesize,comments",
+		"total,tagged,3,3000,2",
+		"total,untagged,1,0,0",
+		"group,ACiD,2,2000,2",
+	} {
+		if i >= len(lines) || lines[i] != want {
+			t.Errorf("CSV line %d = %q, want %q", i, lines, want)
+		}
+	}
+	buf.Reset()
+	if err := r.Write(&buf, stats.Text); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"untagged       1", "ACiD   2      66.7  2.0 kB", "(none)"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("text report = %q, want %q", &buf, want)
+		}
+	}
+	if err := r.Write(&buf, stats.Format(9)); !errors.Is(err, stats.ErrFormat) {
+		t.Errorf("Write() error = %v, want %v", err, stats.ErrFormat)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+	if f, err := stats.ParseFormat("CSV"); err != nil || f != stats.CSV {
+		t.Errorf("ParseFormat(CSV) = %v, %v, want csv", f, err)
+	}
+	if _, err := stats.ParseFormat("xml"); !errors.Is(err, stats.ErrFormat) {
+		t.Errorf("ParseFormat(xml) error = %v, want %v", err, stats.ErrFormat)
+	}
+}
+
+func ExampleTally() {
+	var t stats.Tally
+	for _, group := range []string{"ACiD", "iCE", "ACiD"} {
+		r := sauce.Record{
+			ID:      sauce.ID,
+			Version: sauce.Version,
+			Group:   group,
+			Date:    layout.Dates{Time: time.Date(1996, 1, 1, 0, 0, 0, 0, time.UTC)},
+		}
+		t.Add(&r)
+	}
+	report := t.Report()
+	_ = report.WriteCSV(os.Stdout)
+	// Output:
+	// table,value,files,filesize,comments
+	// total,tagged,3,0,0
+	// total,untagged,0,0,0
+	// group,ACiD,2,0,0
+	// group,iCE,1,0,0
+	// author,,3,0,0
+	// year,1996,3,0,0
+	// datatype,,3,0,0
+	// filetype,,3,0,0
+	// font,,3,0,0
+}
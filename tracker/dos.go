@@ -0,0 +1,156 @@
+package tracker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// composer669 returns the metadata of a Composer 669 or UNIS 669 module,
+// with 8 channels. The 669 format has no song title, so the first line
+// of the song message is used.
+func composer669(b []byte) (*Module, error) {
+	const (
+		message  = 2
+		line     = 36
+		samples  = 0x6e
+		header   = 0x1f1
+		size     = 25 // size of a sample header
+		filename = 13
+		channels = 8
+	)
+	if len(b) < header {
+		return nil, fmt.Errorf("%w: 669", ErrShort)
+	}
+	tracker := "Composer 669"
+	if strings.HasPrefix(string(b), "JN") {
+		tracker = "UNIS 669"
+	}
+	m := &Module{
+		Title:    text(b[message : message+line]),
+		Tracker:  tracker,
+		Channels: channels,
+	}
+	n := int(b[samples])
+	m.Samples = make([]Sample, 0, n)
+	for i := range n {
+		h := field(b, header+i*size, size)
+		if len(h) < size {
+			return m, fmt.Errorf("%w: 669 sample %d", ErrShort, i+1)
+		}
+		m.Samples = append(m.Samples, Sample{Name: text(h[:filename]), Length: le32(h, filename)})
+	}
+	return m, nil
+}
+
+// mtm returns the metadata of a MultiTracker module.
+func mtm(b []byte) (*Module, error) {
+	const (
+		ver      = 3
+		title    = 4
+		titleLen = 20
+		samples  = 30
+		channels = 33
+		header   = 66
+		size     = 37 // size of a sample header
+		name     = 22
+		fine     = 34
+		attr     = 36
+		eighth   = 16
+		bits16   = 1
+	)
+	if len(b) < header {
+		return nil, fmt.Errorf("%w: mtm", ErrShort)
+	}
+	m := &Module{
+		Title:    text(b[title : title+titleLen]),
+		Tracker:  fmt.Sprintf("MultiTracker %d.%d", b[ver]>>4, b[ver]&0x0f),
+		Channels: int(b[channels]),
+	}
+	n := int(b[samples])
+	m.Samples = make([]Sample, 0, n)
+	for i := range n {
+		h := field(b, header+i*size, size)
+		if len(h) < size {
+			return m, fmt.Errorf("%w: mtm sample %d", ErrShort, i+1)
+		}
+		length := le32(h, name)
+		if h[attr]&bits16 != 0 {
+			length *= 2
+		}
+		m.Samples = append(m.Samples, Sample{Name: text(h[:name]), Length: length, Rate: tune(0, finetune(h[fine])*eighth)})
+	}
+	return m, nil
+}
+
+// far returns the metadata of a Farandole Composer module, with 16 channels.
+// The FAR samples are stored after the patterns and are not returned.
+func far(b []byte) (*Module, error) {
+	const (
+		title    = 4
+		titleLen = 40
+		channels = 16
+	)
+	if len(b) < title+titleLen {
+		return nil, fmt.Errorf("%w: far", ErrShort)
+	}
+	return &Module{
+		Title:    text(b[title : title+titleLen]),
+		Tracker:  "Farandole Composer",
+		Channels: channels,
+	}, nil
+}
+
+// ult returns the metadata of an UltraTracker module.
+func ult(b []byte) (*Module, error) {
+	const (
+		ver      = 14
+		title    = 15
+		titleLen = 32
+		message  = 47
+		line     = 32
+		name     = 32
+		start    = 52 // start is the offset of the sample data start
+		end      = 56 // end is the offset of the sample data end
+		c2spd    = 62 // c2spd is the offset of the middle C rate, in version 4 and newer
+		size     = 64
+		orders   = 256
+	)
+	if len(b) <= message {
+		return nil, fmt.Errorf("%w: ult", ErrShort)
+	}
+	v := int(b[ver] - '0')
+	tracker := "UltraTracker"
+	if v >= 1 && v <= 4 {
+		tracker = fmt.Sprintf("UltraTracker 1.%d", v+2)
+	}
+	m := &Module{
+		Title:   text(b[title : title+titleLen]),
+		Tracker: tracker,
+	}
+	i := message + 1 + int(b[message])*line
+	if i >= len(b) {
+		return m, fmt.Errorf("%w: ult samples", ErrShort)
+	}
+	n, sz := int(b[i]), size
+	if v >= 4 {
+		sz += 2
+	}
+	i++
+	m.Samples = make([]Sample, 0, n)
+	for j := range n {
+		h := field(b, i+j*sz, sz)
+		if len(h) < sz {
+			return m, fmt.Errorf("%w: ult sample %d", ErrShort, j+1)
+		}
+		s := Sample{Name: text(h[:name]), Length: max(0, le32(h, end)-le32(h, start)), Rate: C2}
+		if v >= 4 {
+			s.Rate = le16(h, c2spd)
+		}
+		m.Samples = append(m.Samples, s)
+	}
+	// the number of channels, less one, follows the samples and the order list
+	if c := i + n*sz + orders; c < len(b) {
+		m.Channels = int(b[c]) + 1
+	}
+	return m, nil
+}
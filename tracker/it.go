@@ -0,0 +1,97 @@
+package tracker
+
+import "fmt"
+
+// it returns the metadata of an Impulse Tracker module.
+func it(b []byte) (*Module, error) {
+	const (
+		title    = 4
+		nameLen  = 26
+		ordNum   = 0x20
+		insNum   = 0x22
+		smpNum   = 0x24
+		cwt      = 0x28
+		pan      = 0x40
+		maxChans = 64
+		disabled = 0x80
+		orders   = 0xc0
+		ptr      = 4 // ptr is the size of an offset
+		insName  = 0x20
+	)
+	if len(b) < orders {
+		return nil, fmt.Errorf("%w: it", ErrShort)
+	}
+	m := &Module{
+		Title:   text(b[title : title+nameLen]),
+		Tracker: itTracker(le16(b, cwt)),
+	}
+	for _, c := range b[pan : pan+maxChans] {
+		if c < disabled {
+			m.Channels++
+		}
+	}
+	ins, smp := le16(b, insNum), le16(b, smpNum)
+	offsets := orders + le16(b, ordNum)
+	m.Instruments = make([]string, 0, ins)
+	for i := range ins {
+		h := field(b, le32(b, offsets+i*ptr), insName+nameLen)
+		if len(h) < insName+nameLen || string(h[:4]) != "IMPI" {
+			return m, fmt.Errorf("%w: it instrument %d", ErrShort, i+1)
+		}
+		m.Instruments = append(m.Instruments, text(h[insName:insName+nameLen]))
+	}
+	offsets += ins * ptr
+	m.Samples = make([]Sample, 0, smp)
+	for i := range smp {
+		s, err := itSample(b, le32(b, offsets+i*ptr))
+		if err != nil {
+			return m, fmt.Errorf("%w %d", err, i+1)
+		}
+		m.Samples = append(m.Samples, s)
+	}
+	return m, nil
+}
+
+// itSample returns the Impulse Tracker sample at offset i of b.
+func itSample(b []byte, i int) (Sample, error) {
+	const (
+		flags   = 0x12
+		name    = 0x14
+		nameLen = 26
+		length  = 0x30
+		c5speed = 0x3c
+		size    = 0x50
+		bits16  = 1 << 1
+		stereo  = 1 << 2
+	)
+	h := field(b, i, size)
+	if len(h) < size || string(h[:4]) != "IMPS" {
+		return Sample{}, fmt.Errorf("%w: it sample", ErrShort)
+	}
+	n := le32(h, length)
+	if h[flags]&bits16 != 0 {
+		n *= 2
+	}
+	if h[flags]&stereo != 0 {
+		n *= 2
+	}
+	return Sample{Name: text(h[name : name+nameLen]), Length: n, Rate: le32(h, c5speed)}, nil
+}
+
+// itTracker returns the tracker name of the Cwt created with tracker value of an IT module.
+func itTracker(cwt int) string {
+	const (
+		impulse = 0x0
+		schism  = 0x1
+		openmpt = 0x5
+	)
+	switch cwt >> 12 {
+	case impulse:
+		return fmt.Sprintf("Impulse Tracker %d.%02x", cwt>>8&0x0f, cwt&0xff)
+	case schism:
+		return "Schism Tracker"
+	case openmpt:
+		return "OpenMPT"
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+package tracker
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+)
+
+// mod returns the metadata of a MOD module, the Amiga format of
+// SoundTracker, NoiseTracker and ProTracker, with 31 samples.
+func mod(b []byte) (*Module, error) {
+	const (
+		title   = 20
+		samples = 31
+		size    = 30 // size of a sample header
+		sig     = 1080
+		name    = 22
+		length  = 22 // length is the offset of the sample length in words
+		fine    = 24 // fine is the offset of the signed 4-bit fine tune
+		eighth  = 16 // eighth is the fine tune step in 1/128 of a semitone
+	)
+	if len(b) < sig+4 {
+		return nil, fmt.Errorf("%w: mod", ErrShort)
+	}
+	tracker, channels := modSig(string(b[sig : sig+4]))
+	m := &Module{
+		Title:    text(b[:title]),
+		Tracker:  tracker,
+		Channels: channels,
+		Samples:  make([]Sample, samples),
+	}
+	for i := range samples {
+		h := b[title+i*size : title+(i+1)*size]
+		m.Samples[i] = Sample{
+			Name:   text(h[:name]),
+			Length: int(binary.BigEndian.Uint16(h[length:])) * 2,
+			Rate:   tune(0, finetune(h[fine])*eighth),
+		}
+	}
+	return m, nil
+}
+
+// modSig returns the tracker and the number of channels of a MOD signature.
+func modSig(sig string) (string, int) {
+	const amiga, octa = 4, 8
+	switch sig {
+	case "M.K.", "M!K!", "M&K!":
+		return "ProTracker", amiga
+	case "N.T.":
+		return "NoiseTracker", amiga
+	case "FLT4":
+		return "StarTrekker", amiga
+	case "FLT8":
+		return "StarTrekker", octa
+	case "CD81", "OKTA", "OCTA":
+		return "Octalyser", octa
+	}
+	if sig[1:] == "CHN" {
+		n, _ := strconv.Atoi(sig[:1])
+		return "FastTracker", n
+	}
+	n, _ := strconv.Atoi(sig[:2])
+	return "FastTracker", n
+}
+
+// finetune returns the signed 4-bit fine tune of a MOD or MTM sample, in 1/8 of a semitone.
+func finetune(b byte) int {
+	const sign, nibble = 0x08, 0x10
+	n := int(b & 0x0f)
+	if n&sign != 0 {
+		n -= nibble
+	}
+	return n
+}
@@ -0,0 +1,119 @@
+package tracker
+
+import "fmt"
+
+// stm returns the metadata of a Scream Tracker 2 module, with 31 instruments and 4 channels.
+func stm(b []byte) (*Module, error) {
+	const (
+		title    = 20
+		tracker  = 8
+		header   = 48
+		samples  = 31
+		size     = 32 // size of an instrument header
+		filename = 12
+		length   = 16
+		c2spd    = 24
+		channels = 4
+	)
+	if len(b) < header+samples*size {
+		return nil, fmt.Errorf("%w: stm", ErrShort)
+	}
+	m := &Module{
+		Title:    text(b[:title]),
+		Tracker:  text(b[title : title+tracker]),
+		Channels: channels,
+		Samples:  make([]Sample, samples),
+	}
+	for i := range samples {
+		h := b[header+i*size : header+(i+1)*size]
+		m.Samples[i] = Sample{
+			Name:   text(h[:filename]),
+			Length: le16(h, length),
+			Rate:   le16(h, c2spd),
+		}
+	}
+	return m, nil
+}
+
+// s3m returns the metadata of a Scream Tracker 3 module.
+// The S3M instruments are samples or AdLib FM instruments,
+// and the AdLib instruments are returned as samples without any data.
+func s3m(b []byte) (*Module, error) {
+	const (
+		title    = 28
+		ordNum   = 0x20
+		insNum   = 0x22
+		cwt      = 0x28
+		channels = 0x40
+		orders   = 0x60
+		maxChans = 32
+		disabled = 0x80
+		para     = 16 // para is the size of a parapointer paragraph
+		pcm      = 1  // pcm is the instrument type of a digital sample
+	)
+	if len(b) < orders {
+		return nil, fmt.Errorf("%w: s3m", ErrShort)
+	}
+	m := &Module{
+		Title:   text(b[:title]),
+		Tracker: s3mTracker(le16(b, cwt)),
+	}
+	for _, c := range b[channels : channels+maxChans] {
+		if c < disabled {
+			m.Channels++
+		}
+	}
+	ptrs := orders + le16(b, ordNum)
+	n := le16(b, insNum)
+	m.Samples = make([]Sample, 0, n)
+	for i := range n {
+		h := field(b, le16(b, ptrs+i*2)*para, s3mSize)
+		if len(h) < s3mSize {
+			return m, fmt.Errorf("%w: s3m instrument %d", ErrShort, i+1)
+		}
+		s := Sample{Name: text(h[0x30 : 0x30+title])}
+		if h[0] == pcm {
+			s.Length, s.Rate = le32(h, 0x10)*width(h[0x1f]), le32(h, 0x20)
+		}
+		m.Samples = append(m.Samples, s)
+	}
+	return m, nil
+}
+
+// s3mSize is the size of a Scream Tracker 3 instrument header.
+const s3mSize = 0x50
+
+// width returns the number of bytes of a sample frame of the S3M sample flags.
+func width(flags byte) int {
+	const stereo, bits16 = 1 << 1, 1 << 2
+	n := 1
+	if flags&stereo != 0 {
+		n *= 2
+	}
+	if flags&bits16 != 0 {
+		n *= 2
+	}
+	return n
+}
+
+// s3mTracker returns the tracker name of the Cwt/v created with tracker value of a S3M module.
+func s3mTracker(cwt int) string {
+	const (
+		scream  = 0x1
+		impulse = 0x3
+		schism  = 0x4
+		openmpt = 0x5
+	)
+	major, minor := cwt>>8&0x0f, cwt&0xff
+	switch cwt >> 12 {
+	case scream:
+		return fmt.Sprintf("Scream Tracker %d.%02x", major, minor)
+	case impulse:
+		return fmt.Sprintf("Impulse Tracker %d.%02x", major, minor)
+	case schism:
+		return "Schism Tracker"
+	case openmpt:
+		return "OpenMPT"
+	}
+	return ""
+}
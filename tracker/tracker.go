@@ -0,0 +1,206 @@
+// Package tracker reads the metadata of tracker music modules.
+//
+// Tracker modules combine digital samples with patterns of notes, and were
+// the music format of the demoscene and the artpacks that used SAUCE.
+// The module headers contain the song title, the number of channels and the
+// names of the instruments and samples, which authors often used to hold
+// greetings and credits.
+//
+// See http://www.acid.org/info/sauce/sauce.htm#FileType
+package tracker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/internal/cp437"
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+// C2 is the sample rate in Hz of the middle C note of an Amiga module,
+// which is the default rate of the samples of most formats.
+const C2 = 8363
+
+var (
+	ErrFormat   = errors.New("unknown or unsupported module format")
+	ErrShort    = errors.New("module header is too short")
+	ErrDataType = errors.New("record data type is not audio")
+	ErrFileType = errors.New("record file type does not match the module")
+	ErrTitle    = errors.New("record title does not match the module")
+)
+
+// Module is the metadata of a tracker module.
+type Module struct {
+	Type        sauce.FileType // type is the SAUCE audio file type
+	Tracker     string         // tracker is the name of the software that created the module, when known
+	Title       string         // title of the song
+	Channels    int            // channels is the number of channels or tracks
+	Instruments []string       // instruments are the names of the instruments, used by XM and IT modules
+	Samples     []Sample       // samples are the digital samples, including those without any data
+}
+
+// Sample is a digital sample of a module.
+type Sample struct {
+	Name   string // name of the sample, which is often used for messages
+	Length int    // length of the sample in bytes
+	Rate   int    // rate is the sample rate in Hz of the middle C note, or 0 when unknown
+}
+
+// Parse returns the metadata of the tracker module b.
+// The format is identified by the signatures in the module header using [sauce.Sniff].
+func Parse(b []byte) (*Module, error) {
+	dt, ft, _ := sauce.Sniff(b)
+	if dt != sauce.DataAudio {
+		return nil, ErrFormat
+	}
+	var parse func([]byte) (*Module, error)
+	switch ft {
+	case sauce.AudioMod:
+		parse = mod
+	case sauce.AudioComposer669:
+		parse = composer669
+	case sauce.AudioStm:
+		parse = stm
+	case sauce.AudioS3m:
+		parse = s3m
+	case sauce.AudioMtm:
+		parse = mtm
+	case sauce.AudioFar:
+		parse = far
+	case sauce.AudioUlt:
+		parse = ult
+	case sauce.AudioXm:
+		parse = xm
+	case sauce.AudioIt:
+		parse = it
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrFormat, layout.Audio(ft))
+	}
+	m, err := parse(b)
+	if err != nil {
+		return nil, err
+	}
+	m.Type = ft
+	return m, nil
+}
+
+// Fill sets the data type, file type, title and sample rate of the SAUCE record r
+// to the metadata of the module. The title is truncated to the 35 character
+// limit of SAUCE and is only set when the module has a song title,
+// while the TInfo1 sample rate is only set when it is known.
+func (m *Module) Fill(r *sauce.Record) {
+	r.Data = layout.Datas{Type: layout.Audios, Name: layout.Audios.String()}
+	r.File = layout.Files{Type: m.Type, Name: layout.Audio(m.Type).String()}
+	if title := m.title(); title != "" {
+		r.Title = title
+	}
+	if rate := m.Rate(); rate > 0 && rate <= math.MaxUint16 {
+		r.Info.Info1 = layout.Info{Value: uint16(rate), Info: "sample rate"}
+	}
+}
+
+// Tag fills the SAUCE record r with the metadata of the tracker module b,
+// and is a [sauce.Tagger] for use with [sauce.AutoTag].
+// The record is unchanged when b is not a supported module.
+func Tag(b []byte, r *sauce.Record) {
+	m, err := Parse(b)
+	if err != nil {
+		return
+	}
+	m.Fill(r)
+}
+
+// Check returns an error when the SAUCE record r is not an audio data type,
+// its file type is not the module format, or its title differs from the song title.
+func (m *Module) Check(r *sauce.Record) error {
+	if r == nil || r.Data.Type != sauce.DataAudio {
+		return ErrDataType
+	}
+	var errs []error
+	if r.File.Type != m.Type {
+		errs = append(errs, fmt.Errorf("%w: %s, want %s", ErrFileType, r.File.Name, layout.Audio(m.Type)))
+	}
+	if title := strings.TrimSpace(r.Title); title != "" && m.Title != "" && !strings.EqualFold(title, m.title()) {
+		errs = append(errs, fmt.Errorf("%w: %q, want %q", ErrTitle, title, m.title()))
+	}
+	return errors.Join(errs...)
+}
+
+// title returns the song title truncated to the 35 characters of a SAUCE title.
+func (m *Module) title() string {
+	const size = 35
+	if s := []rune(m.Title); len(s) > size {
+		return strings.TrimSpace(string(s[:size]))
+	}
+	return m.Title
+}
+
+// Rate returns the most common sample rate of the samples that contain data,
+// or 0 when it is unknown.
+func (m *Module) Rate() int {
+	count := map[int]int{}
+	best := 0
+	for _, s := range m.Samples {
+		if s.Length == 0 || s.Rate == 0 {
+			continue
+		}
+		count[s.Rate]++
+		if count[s.Rate] > count[best] || (count[s.Rate] == count[best] && s.Rate < best) {
+			best = s.Rate
+		}
+	}
+	return best
+}
+
+// tune returns the sample rate of a middle C note that is transposed by
+// the relative semitones and fine tune, which is in 1/128 of a semitone.
+func tune(relative, fine int) int {
+	const semitone, octave = 128, 12
+	return int(math.Round(C2 * math.Pow(2, float64(relative*semitone+fine)/(octave*semitone))))
+}
+
+// text returns the CP437 text of a fixed length field,
+// which ends at the first NUL and uses spaces for any control codes.
+func text(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i > -1 {
+		b = b[:i]
+	}
+	s := make([]byte, len(b))
+	for i, c := range b {
+		if c < ' ' {
+			c = ' '
+		}
+		s[i] = c
+	}
+	return strings.TrimSpace(cp437.String(s))
+}
+
+// le16 returns the little-endian uint16 at offset i of b, or 0 when b is too short.
+func le16(b []byte, i int) int {
+	if i < 0 || i+2 > len(b) {
+		return 0
+	}
+	return int(binary.LittleEndian.Uint16(b[i:]))
+}
+
+// le32 returns the little-endian uint32 at offset i of b, or 0 when b is too short.
+func le32(b []byte, i int) int {
+	if i < 0 || i+4 > len(b) {
+		return 0
+	}
+	return int(binary.LittleEndian.Uint32(b[i:]))
+}
+
+// field returns the bytes of b from offset i of the length n,
+// or the bytes that remain when b is too short.
+func field(b []byte, i, n int) []byte {
+	if i < 0 || i >= len(b) {
+		return nil
+	}
+	return b[i:min(len(b), i+n)]
+}
@@ -0,0 +1,215 @@
+package tracker_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bengarrett/sauce"
+	"github.com/bengarrett/sauce/tracker"
+)
+
+// modFile returns a 4 channel ProTracker module with a single named sample.
+func modFile() []byte {
+	b := make([]byte, 1084)
+	copy(b, "Space Debris\x00")
+	copy(b[20:], "by Captain")
+	binary.BigEndian.PutUint16(b[42:], 0x1000)
+	copy(b[1080:], "M.K.")
+	return b
+}
+
+// mtmFile returns a MultiTracker 1.0 module with 4 channels and a 16-bit sample.
+func mtmFile() []byte {
+	b := make([]byte, 66+37)
+	copy(b, "MTM\x10Dreams")
+	b[30], b[33] = 1, 4
+	copy(b[66:], "Harp")
+	binary.LittleEndian.PutUint32(b[66+22:], 50)
+	b[66+36] = 1
+	return b
+}
+
+// s3mFile returns a Scream Tracker 3.20 module with 8 channels and one instrument.
+func s3mFile() []byte {
+	b := make([]byte, 0x100)
+	copy(b, "Second Reality")
+	b[0x1d] = 16
+	binary.LittleEndian.PutUint16(b[0x20:], 2) // orders
+	binary.LittleEndian.PutUint16(b[0x22:], 1) // instruments
+	binary.LittleEndian.PutUint16(b[0x28:], 0x1320)
+	copy(b[44:], "SCRM")
+	for i := range 32 {
+		b[0x40+i] = 0xff
+		if i < 8 {
+			b[0x40+i] = byte(i)
+		}
+	}
+	binary.LittleEndian.PutUint16(b[0x62:], 0x07) // parapointer
+	h := b[0x70:]
+	h[0] = 1
+	binary.LittleEndian.PutUint32(h[0x10:], 100)
+	h[0x1f] = 1 << 2 // 16-bit
+	binary.LittleEndian.PutUint32(h[0x20:], 22050)
+	copy(h[0x30:], "Purple Motion")
+	copy(h[0x4c:], "SCRS")
+	return b
+}
+
+// xmFile returns a FastTracker 2 module with one empty pattern and one instrument.
+func xmFile() []byte {
+	b := make([]byte, 60+20+9)
+	copy(b, "Extended Module: Catch that goblin!!\x1a")
+	copy(b[38:], "FastTracker v2.00")
+	binary.LittleEndian.PutUint32(b[60:], 20)
+	binary.LittleEndian.PutUint16(b[68:], 6)
+	binary.LittleEndian.PutUint16(b[70:], 1)
+	binary.LittleEndian.PutUint16(b[72:], 1)
+	binary.LittleEndian.PutUint32(b[80:], 9) // pattern header length
+	ins := make([]byte, 33)
+	binary.LittleEndian.PutUint32(ins, 33)
+	copy(ins[4:], "Lead")
+	binary.LittleEndian.PutUint16(ins[27:], 1)
+	binary.LittleEndian.PutUint32(ins[29:], 40)
+	smp := make([]byte, 40)
+	binary.LittleEndian.PutUint32(smp, 4)
+	smp[16] = 12 // one octave up
+	copy(smp[18:], "Guitar")
+	b = append(b, ins...)
+	b = append(b, smp...)
+	return append(b, 1, 2, 3, 4)
+}
+
+// itFile returns an Impulse Tracker 2.14 module with one instrument and one sample.
+func itFile() []byte {
+	b := make([]byte, 0xc0+8)
+	copy(b, "IMPMBeyond Music")
+	binary.LittleEndian.PutUint16(b[0x22:], 1)
+	binary.LittleEndian.PutUint16(b[0x24:], 1)
+	binary.LittleEndian.PutUint16(b[0x28:], 0x0214)
+	for i := range 64 {
+		b[0x40+i] = 0xa0
+		if i < 4 {
+			b[0x40+i] = 32
+		}
+	}
+	binary.LittleEndian.PutUint32(b[0xc0:], uint32(len(b)))
+	ins := make([]byte, 0x3a)
+	copy(ins, "IMPI")
+	copy(ins[0x20:], "Strings")
+	binary.LittleEndian.PutUint32(b[0xc4:], uint32(len(b)+len(ins)))
+	smp := make([]byte, 0x50)
+	copy(smp, "IMPS")
+	copy(smp[0x14:], "Violin")
+	binary.LittleEndian.PutUint32(smp[0x30:], 10)
+	binary.LittleEndian.PutUint32(smp[0x3c:], 44100)
+	b = append(b, ins...)
+	return append(b, smp...)
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		b        []byte
+		typ      sauce.FileType
+		title    string
+		tracker  string
+		channels int
+		sample   tracker.Sample
+		inst     string
+	}{
+		{"mod", modFile(), sauce.AudioMod, "Space Debris", "ProTracker", 4,
+			tracker.Sample{Name: "by Captain", Length: 0x2000, Rate: tracker.C2}, ""},
+		{"mtm", mtmFile(), sauce.AudioMtm, "Dreams", "MultiTracker 1.0", 4,
+			tracker.Sample{Name: "Harp", Length: 100, Rate: tracker.C2}, ""},
+		{"s3m", s3mFile(), sauce.AudioS3m, "Second Reality", "Scream Tracker 3.20", 8,
+			tracker.Sample{Name: "Purple Motion", Length: 200, Rate: 22050}, ""},
+		{"xm", xmFile(), sauce.AudioXm, "Catch that goblin!!", "FastTracker v2.00", 6,
+			tracker.Sample{Name: "Guitar", Length: 4, Rate: tracker.C2 * 2}, "Lead"},
+		{"it", itFile(), sauce.AudioIt, "Beyond Music", "Impulse Tracker 2.14", 4,
+			tracker.Sample{Name: "Violin", Length: 10, Rate: 44100}, "Strings"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m, err := tracker.Parse(tt.b)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if m.Type != tt.typ || m.Title != tt.title || m.Tracker != tt.tracker || m.Channels != tt.channels {
+				t.Errorf("Parse() = %d, %q, %q, %d, want %d, %q, %q, %d",
+					m.Type, m.Title, m.Tracker, m.Channels, tt.typ, tt.title, tt.tracker, tt.channels)
+			}
+			if len(m.Samples) == 0 || m.Samples[0] != tt.sample {
+				t.Errorf("Parse() samples = %v, want %v", m.Samples, tt.sample)
+			}
+			if tt.inst != "" && (len(m.Instruments) != 1 || m.Instruments[0] != tt.inst) {
+				t.Errorf("Parse() instruments = %q, want %q", m.Instruments, tt.inst)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	t.Parallel()
+	if _, err := tracker.Parse([]byte("hello world")); !errors.Is(err, tracker.ErrFormat) {
+		t.Errorf("Parse() error = %v, want %v", err, tracker.ErrFormat)
+	}
+	if _, err := tracker.Parse([]byte("MThd\x00\x00\x00\x06")); !errors.Is(err, tracker.ErrFormat) {
+		t.Errorf("Parse() midi error = %v, want %v", err, tracker.ErrFormat)
+	}
+	b := s3mFile()
+	if _, err := tracker.Parse(b[:0x90]); !errors.Is(err, tracker.ErrShort) {
+		t.Errorf("Parse() truncated error = %v, want %v", err, tracker.ErrShort)
+	}
+}
+
+func TestModule_Check(t *testing.T) {
+	t.Parallel()
+	m, err := tracker.Parse(modFile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := sauce.Record{}
+	if err := m.Check(&r); !errors.Is(err, tracker.ErrDataType) {
+		t.Errorf("Check() error = %v, want %v", err, tracker.ErrDataType)
+	}
+	m.Fill(&r)
+	if err := m.Check(&r); err != nil {
+		t.Errorf("Check() after Fill() error = %v", err)
+	}
+	if r.Title != "Space Debris" || r.Info.Info1.Value != tracker.C2 {
+		t.Errorf("Fill() = %q, %d", r.Title, r.Info.Info1.Value)
+	}
+	r.Title = "Stardust Memories"
+	r.File.Type = sauce.AudioS3m
+	err = m.Check(&r)
+	if !errors.Is(err, tracker.ErrTitle) || !errors.Is(err, tracker.ErrFileType) {
+		t.Errorf("Check() error = %v, want %v and %v", err, tracker.ErrTitle, tracker.ErrFileType)
+	}
+}
+
+func ExampleParse() {
+	b := make([]byte, 1084)
+	copy(b, "Space Debris")
+	copy(b[1080:], "8CHN")
+	m, err := tracker.Parse(b)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("%s by %s, %d channels\n", m.Title, m.Tracker, m.Channels)
+	fmt.Println(m.Type == sauce.AudioMod)
+	// Output: Space Debris by FastTracker, 8 channels
+	// true
+}
+
+func TestTag(t *testing.T) {
+	t.Parallel()
+	r := sauce.AutoTag("music/debris.mod", modFile(), sauce.TagOptions{Taggers: []sauce.Tagger{tracker.Tag}})
+	if r.Title != "Space Debris" || r.File.Type != sauce.AudioMod || r.Info.Info1.Value != tracker.C2 {
+		t.Errorf("AutoTag() with Tag = %q, %s, %d", r.Title, r.File.Name, r.Info.Info1.Value)
+	}
+}
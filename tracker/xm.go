@@ -0,0 +1,84 @@
+package tracker
+
+import "fmt"
+
+// xm returns the metadata of a FastTracker 2 Extended Module.
+func xm(b []byte) (*Module, error) {
+	const (
+		title    = 17
+		titleLen = 20
+		tracker  = 38
+		size     = 60 // size is the offset of the header size, which is measured from this offset
+		channels = 68
+		patterns = 70
+		insts    = 72
+		packed   = 7 // packed is the offset of the packed pattern data size
+	)
+	if len(b) < insts+2 {
+		return nil, fmt.Errorf("%w: xm", ErrShort)
+	}
+	m := &Module{
+		Title:    text(b[title : title+titleLen]),
+		Tracker:  text(b[tracker : tracker+titleLen]),
+		Channels: le16(b, channels),
+	}
+	i := size + le32(b, size)
+	for range le16(b, patterns) {
+		if i >= len(b) {
+			return m, fmt.Errorf("%w: xm patterns", ErrShort)
+		}
+		i += le32(b, i) + le16(b, i+packed)
+	}
+	n := le16(b, insts)
+	m.Instruments = make([]string, 0, n)
+	for j := range n {
+		next, err := m.xmInstrument(b, i)
+		if err != nil {
+			return m, fmt.Errorf("%w %d", err, j+1)
+		}
+		i = next
+	}
+	return m, nil
+}
+
+// xmInstrument appends the instrument and its samples at offset i of b,
+// and returns the offset of the next instrument.
+func (m *Module) xmInstrument(b []byte, i int) (int, error) {
+	const (
+		name      = 4
+		nameLen   = 22
+		samples   = 27
+		smpHeader = 29 // smpHeader is the offset of the sample header size
+		length    = 0
+		fine      = 13
+		relative  = 16
+		smpName   = 18
+	)
+	h := field(b, i, samples+2)
+	if len(h) < samples+2 {
+		return 0, fmt.Errorf("%w: xm instrument", ErrShort)
+	}
+	m.Instruments = append(m.Instruments, text(h[name:name+nameLen]))
+	n := le16(b, i+samples)
+	hsize := le32(b, i+smpHeader)
+	i += le32(b, i)
+	if n == 0 {
+		return i, nil
+	}
+	data := 0
+	for range n {
+		s := field(b, i, hsize)
+		if hsize < smpName+nameLen || len(s) < smpName+nameLen {
+			return 0, fmt.Errorf("%w: xm sample", ErrShort)
+		}
+		size := le32(s, length)
+		m.Samples = append(m.Samples, Sample{
+			Name:   text(s[smpName : smpName+nameLen]),
+			Length: size,
+			Rate:   tune(int(int8(s[relative])), int(int8(s[fine]))),
+		})
+		data += size
+		i += hsize
+	}
+	return i + data, nil
+}
@@ -0,0 +1,106 @@
+package sauce
+
+import "github.com/bengarrett/sauce/internal/layout"
+
+// DataType is the SAUCE DataType, which is found in the Data.Type field of a record
+// and is returned by [Sniff].
+type DataType = layout.TypeOfData
+
+// FileType is the SAUCE FileType, which is found in the File.Type field of a record
+// and is returned by [Sniff]. The meaning of a FileType depends on the DataType.
+type FileType = layout.TypeOfFile
+
+// DataType values.
+const (
+	DataNone       = layout.Nones       // undefined data type
+	DataCharacter  = layout.Characters  // characters and plain text based files
+	DataBitmap     = layout.Bitmaps     // bitmap, graphic and animation files
+	DataVector     = layout.Vectors     // vector graphic files
+	DataAudio      = layout.Audios      // audio and sound files
+	DataBinaryText = layout.BinaryTexts // raw memory copies of a text mode screen, where the FileType is half the width
+	DataXBin       = layout.XBins       // xbin or extended bin files
+	DataArchive    = layout.Archives    // archived files such as a zip package
+	DataExecutable = layout.Executables // executable files
+)
+
+// FileType values of the character data type.
+const (
+	CharacterASCII      = FileType(layout.ASCII)      // ASCII text
+	CharacterAnsi       = FileType(layout.Ansi)       // ANSI color text
+	CharacterAnsiMation = FileType(layout.AnsiMation) // ANSIMation
+	CharacterRipScript  = FileType(layout.RipScript)  // RIPScript
+	CharacterPCBoard    = FileType(layout.PCBoard)    // PCBoard color text
+	CharacterAvatar     = FileType(layout.Avatar)     // Avatar color text
+	CharacterHTML       = FileType(layout.HTML)       // HTML markup
+	CharacterSource     = FileType(layout.Source)     // Programming source code
+	CharacterTundraDraw = FileType(layout.TundraDraw) // TundraDraw color text
+)
+
+// FileType values of the bitmap data type.
+const (
+	BitmapGif = FileType(layout.Gif) // GIF image
+	BitmapPcx = FileType(layout.Pcx) // ZSoft Paintbrush image
+	BitmapLbm = FileType(layout.Lbm) // DeluxePaint image
+	BitmapTga = FileType(layout.Tga) // Targa true color image
+	BitmapFli = FileType(layout.Fli) // Autodesk Animator animation
+	BitmapFlc = FileType(layout.Flc) // Autodesk Animator animation
+	BitmapBmp = FileType(layout.Bmp) // BMP Windows/OS2 bitmap
+	BitmapGl  = FileType(layout.Gl)  // Grasp GL animation
+	BitmapDl  = FileType(layout.Dl)  // DL animation
+	BitmapWpg = FileType(layout.Wpg) // WordPerfect graphic
+	BitmapPng = FileType(layout.Png) // PNG image
+	BitmapJpg = FileType(layout.Jpg) // Jpeg photo
+	BitmapMpg = FileType(layout.Mpg) // MPEG video
+	BitmapAvi = FileType(layout.Avi) // AVI video
+)
+
+// FileType values of the vector data type.
+const (
+	VectorDxf     = FileType(layout.Dxf)     // AutoDesk CAD vector graphic
+	VectorDwg     = FileType(layout.Dwg)     // AutoDesk CAD vector graphic
+	VectorWpvg    = FileType(layout.Wpvg)    // WordPerfect vector graphic
+	VectorKinetix = FileType(layout.Kinetix) // 3D Studio vector graphic
+)
+
+// FileType values of the audio data type.
+const (
+	AudioMod         = FileType(layout.Mod)         // NoiseTracker module
+	AudioComposer669 = FileType(layout.Composer669) // Composer 669 module
+	AudioStm         = FileType(layout.Stm)         // ScreamTracker module
+	AudioS3m         = FileType(layout.S3m)         // ScreamTracker 3 module
+	AudioMtm         = FileType(layout.Mtm)         // MultiTracker module
+	AudioFar         = FileType(layout.Far)         // Farandole Composer module
+	AudioUlt         = FileType(layout.Ult)         // Ultra Tracker module
+	AudioAmf         = FileType(layout.Amf)         // Dual Module Player module
+	AudioDmf         = FileType(layout.Dmf)         // X-Tracker module
+	AudioOkt         = FileType(layout.Okt)         // Oktalyzer module
+	AudioRol         = FileType(layout.Rol)         // AdLib Visual Composer FM audio
+	AudioCmf         = FileType(layout.Cmf)         // Creative Music FM audio
+	AudioMidi        = FileType(layout.Midi)        // MIDI audio
+	AudioSadt        = FileType(layout.Sadt)        // SAdT composer FM audio
+	AudioVoc         = FileType(layout.Voc)         // Creative Voice File
+	AudioWave        = FileType(layout.Wave)        // Waveform audio
+	AudioSmp8        = FileType(layout.Smp8)        // single channel 8-bit sample
+	AudioSmp8s       = FileType(layout.Smp8s)       // stereo 8-bit sample
+	AudioSmp16       = FileType(layout.Smp16)       // single channel 16-bit sample
+	AudioSmp16s      = FileType(layout.Smp16s)      // stereo 16-bit sample
+	AudioPatch8      = FileType(layout.Patch8)      // 8-bit patch file
+	AudioPatch16     = FileType(layout.Patch16)     // 16-bit patch file
+	AudioXm          = FileType(layout.Xm)          // Extended Module
+	AudioHsc         = FileType(layout.Hsc)         // Hannes Seifert Composition FM audio
+	AudioIt          = FileType(layout.It)          // Impulse Tracker module
+)
+
+// FileType values of the archive data type.
+const (
+	ArchiveZip = FileType(layout.Zip) // ZIP compressed archive
+	ArchiveArj = FileType(layout.Arj) // ARJ compressed archive
+	ArchiveLzh = FileType(layout.Lzh) // LHA compressed archive
+	ArchiveArc = FileType(layout.Arc) // ARC compressed archive
+	ArchiveTar = FileType(layout.Tar) // Tarball tape archive
+	ArchiveZoo = FileType(layout.Zoo) // ZOO compressed archive
+	ArchiveRar = FileType(layout.Rar) // RAR compressed archive
+	ArchiveUc2 = FileType(layout.Uc2) // UltraCompressor II compressed archive
+	ArchivePak = FileType(layout.Pak) // PAK ARC compressed archive
+	ArchiveSqz = FileType(layout.Sqz) // Squeeze It compressed archive
+)
@@ -0,0 +1,122 @@
+package sauce
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/bengarrett/sauce/internal/layout"
+)
+
+var (
+	ErrComments = errors.New("too many comment lines")
+	ErrClosed   = errors.New("sauce writer is closed")
+)
+
+// MarshalBinary returns the SAUCE metadata of the record in its binary layout,
+// which is the optional comment block followed by the 128 byte SAUCE record.
+// The end-of-file marker, which should separate the metadata from the content, is not included.
+//
+// The text fields are truncated to the length of the SAUCE fields, the FileSize
+// is the FileSize.Bytes value, and a record without a date uses the current date.
+// An error is returned when there are more than 255 lines of comments.
+func (r *Record) MarshalBinary() ([]byte, error) {
+	return r.marshal(r.FileSize.Bytes)
+}
+
+// marshal returns the comment block and the SAUCE record with the file size in bytes.
+func (r *Record) marshal(size uint32) ([]byte, error) {
+	lines := r.Comnt.Comment
+	if len(lines) > layout.ComntMaxLines {
+		return nil, fmt.Errorf("%w: %d lines", ErrComments, len(lines))
+	}
+	b := []byte{}
+	if len(lines) > 0 {
+		b = append(b, layout.ComntID...)
+		for _, line := range lines {
+			b = append(b, field(line, layout.ComntLineSize, ' ')...)
+		}
+	}
+	return append(b, r.record(size)...), nil
+}
+
+// record returns the SAUCE record in its binary layout, with the file size in bytes.
+// The comment block is not included.
+func (r *Record) record(size uint32) []byte {
+	const (
+		sauceLen = 128
+		titleLen = 35
+		nameLen  = 20
+		fontLen  = 22
+	)
+	date := r.Date.Time
+	if date.IsZero() {
+		date = time.Now()
+	}
+	b := make([]byte, 0, sauceLen)
+	b = append(b, ID+Version...)
+	b = append(b, field(r.Title, titleLen, ' ')...)
+	b = append(b, field(r.Author, nameLen, ' ')...)
+	b = append(b, field(r.Group, nameLen, ' ')...)
+	b = append(b, date.Format(Date)...)
+	b = binary.LittleEndian.AppendUint32(b, size)
+	b = append(b, byte(r.Data.Type), byte(r.File.Type))
+	b = binary.LittleEndian.AppendUint16(b, r.Info.Info1.Value)
+	b = binary.LittleEndian.AppendUint16(b, r.Info.Info2.Value)
+	b = binary.LittleEndian.AppendUint16(b, r.Info.Info3.Value)
+	b = binary.LittleEndian.AppendUint16(b, 0)
+	comments := min(len(r.Comnt.Comment), layout.ComntMaxLines)
+	b = append(b, byte(comments), byte(r.Info.Flags.Decimal))
+	return append(b, field(r.Info.Font, fontLen, 0)...)
+}
+
+// field returns s as a fixed length field of n bytes that is padded with c.
+func field(s string, n int, c byte) []byte {
+	b := bytes.Repeat([]byte{c}, n)
+	copy(b, s)
+	return b
+}
+
+// writer appends a SAUCE record to the content written to w.
+type writer struct {
+	w      io.Writer
+	rec    Record
+	n      uint64
+	closed bool
+}
+
+// NewWriter returns a writer that passes the content through to w,
+// and on Close writes the end-of-file marker and the SAUCE metadata of rec.
+// The FileSize of the record is set to the number of bytes of content written.
+// Close does not close w.
+func NewWriter(w io.Writer, rec Record) io.WriteCloser {
+	return &writer{w: w, rec: rec}
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, ErrClosed
+	}
+	n, err := w.w.Write(p)
+	w.n += uint64(n) //nolint:gosec
+	return n, err
+}
+
+// Close writes the end-of-file marker and the SAUCE metadata.
+func (w *writer) Close() error {
+	if w.closed {
+		return ErrClosed
+	}
+	w.closed = true
+	b, err := w.rec.marshal(uint32(min(w.n, 1<<32-1)))
+	if err != nil {
+		return err
+	}
+	if _, err := w.w.Write(append([]byte{EOF}, b...)); err != nil {
+		return fmt.Errorf("write sauce: %w", err)
+	}
+	return nil
+}
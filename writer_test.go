@@ -0,0 +1,88 @@
+package sauce_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bengarrett/sauce"
+)
+
+func TestRecord_MarshalBinary(t *testing.T) {
+	t.Parallel()
+	raw, err := static.ReadFile(example)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := sauce.Decode(raw)
+	b, err := want.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary() error = %v", err)
+	}
+	content := sauce.Trim(raw)
+	if !bytes.HasSuffix(raw, b) {
+		t.Errorf("MarshalBinary() is not the same as the original metadata")
+	}
+	got := sauce.Decode(append(append(content, sauce.EOF), b...))
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Decode(MarshalBinary()) = %+v, want %+v", got, want)
+	}
+	want.Comnt.Comment = make([]string, 256)
+	if _, err := want.MarshalBinary(); !errors.Is(err, sauce.ErrComments) {
+		t.Errorf("MarshalBinary() error = %v, want %v", err, sauce.ErrComments)
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	t.Parallel()
+	rec := sauce.Record{Title: "a title that is much longer than the 35 byte field"}
+	rec.Data.Type = 1
+	rec.Comnt.Comment = []string{"first line", "second line"}
+	rec.FileSize.Bytes = 99
+	var buf bytes.Buffer
+	w := sauce.NewWriter(&buf, rec)
+	content := strings.Repeat("hello world\r\n", 100)
+	if _, err := io.WriteString(w, content); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := w.Close(); !errors.Is(err, sauce.ErrClosed) {
+		t.Errorf("Close() error = %v, want %v", err, sauce.ErrClosed)
+	}
+	if _, err := w.Write([]byte("x")); !errors.Is(err, sauce.ErrClosed) {
+		t.Errorf("Write() error = %v, want %v", err, sauce.ErrClosed)
+	}
+	b := buf.Bytes()
+	if got := string(sauce.Trim(b)); got != content {
+		t.Errorf("Trim() = %d bytes, want %d", len(got), len(content))
+	}
+	r := sauce.Decode(b)
+	if !r.Valid() || int(r.FileSize.Bytes) != len(content) {
+		t.Errorf("Decode() file size = %d, want %d", r.FileSize.Bytes, len(content))
+	}
+	if r.Title != rec.Title[:35] {
+		t.Errorf("Decode() title = %q", r.Title)
+	}
+	if len(r.Comnt.Comment) != 2 || strings.TrimSpace(r.Comnt.Comment[1]) != "second line" {
+		t.Errorf("Decode() comments = %q", r.Comnt.Comment)
+	}
+}
+
+func ExampleNewWriter() {
+	var buf bytes.Buffer
+	rec := sauce.Record{Title: "Hello", Author: "Ben"}
+	w := sauce.NewWriter(&buf, rec)
+	fmt.Fprint(w, "Hello world")
+	if err := w.Close(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	r := sauce.Decode(buf.Bytes())
+	fmt.Printf("%s by %s, %d bytes\n", r.Title, r.Author, r.FileSize.Bytes)
+	// Output: Hello by Ben, 11 bytes
+}